@@ -0,0 +1,49 @@
+package leapmotion
+
+import "math"
+
+// updateInteractionBox folds box into the client's interaction box. c.mu must
+// be held.
+func (c *Client) updateInteractionBox(box *InteractionBox) {
+	if len(box.Center) < 3 || len(box.Size) < 3 {
+		return
+	}
+
+	alpha := c.opts.boxAlpha
+	if alpha <= 0 || alpha > 1 || c.boxCenter == nil {
+		alpha = 1
+	}
+	if c.boxCenter == nil {
+		c.boxCenter = make([]float64, 3)
+		c.boxSize = make([]float64, 3)
+	}
+
+	for k := 0; k < 3; k++ {
+		c.boxCenter[k] += alpha * (float64(box.Center[k]) - c.boxCenter[k])
+		c.boxSize[k] += alpha * (box.Size[k] - c.boxSize[k])
+	}
+}
+
+// InteractionBox returns the interaction box of the latest frame or, with
+// WithSmoothedInteractionBox, the smoothed interaction box. Use it in place of
+// the per frame box to keep normalized coordinates from wobbling. The box is
+// empty until a frame has been received.
+func (c *Client) InteractionBox() InteractionBox {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.boxCenter == nil {
+		return InteractionBox{}
+	}
+
+	box := InteractionBox{
+		Center: make([]int, 3),
+		Size:   make([]float64, 3),
+	}
+	for k := 0; k < 3; k++ {
+		box.Center[k] = int(math.Round(c.boxCenter[k]))
+		box.Size[k] = c.boxSize[k]
+	}
+
+	return box
+}
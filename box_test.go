@@ -0,0 +1,25 @@
+package leapmotion
+
+import "testing"
+
+func TestClientInteractionBox(t *testing.T) {
+	tests := []struct {
+		alpha    float64
+		expected float64
+	}{
+		{0, 300},   // per frame box
+		{0.5, 250}, // smoothed box
+	}
+
+	for _, test := range tests {
+		c := &Client{}
+		WithSmoothedInteractionBox(test.alpha)(&c.opts)
+
+		c.updateInteractionBox(&InteractionBox{Center: []int{0, 200, 0}, Size: []float64{200, 200, 200}})
+		c.updateInteractionBox(&InteractionBox{Center: []int{0, 200, 0}, Size: []float64{300, 200, 200}})
+
+		if box := c.InteractionBox(); box.Size[0] != test.expected {
+			t.Fatalf("Received width %f. Expected %f", box.Size[0], test.expected)
+		}
+	}
+}
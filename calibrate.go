@@ -0,0 +1,88 @@
+package leapmotion
+
+import (
+	"fmt"
+	"math"
+	"sync"
+)
+
+// Calibrator learns the volume a user actually reaches, which is usually a
+// part of the interaction box the service reports. Feed it every frame with
+// Update while guiding the user to reach around, then use Box for
+// NormalizePoint so the user's reach maps to the full [0..1] range.
+type Calibrator struct {
+	mu      sync.Mutex
+	min     []float64
+	max     []float64
+	samples int
+}
+
+// NewCalibrator returns a Calibrator that hasn't recorded anything yet
+func NewCalibrator() *Calibrator {
+	return &Calibrator{}
+}
+
+// Update extends the recorded volume with the tip positions of the fingers
+// in frame. Tools aren't included.
+func (c *Calibrator) Update(frame *Frame) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for _, p := range frame.Pointables {
+		if p.Tool || !isVector(p.TipPosition) {
+			continue
+		}
+
+		if c.samples == 0 {
+			c.min = copyVector(p.TipPosition)
+			c.max = copyVector(p.TipPosition)
+		}
+		for k := 0; k < 3; k++ {
+			c.min[k] = math.Min(c.min[k], p.TipPosition[k])
+			c.max[k] = math.Max(c.max[k], p.TipPosition[k])
+		}
+		c.samples++
+	}
+}
+
+// Samples returns the number of fingertip positions recorded
+func (c *Calibrator) Samples() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.samples
+}
+
+// Reset forgets the recorded volume to calibrate again
+func (c *Calibrator) Reset() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.min, c.max, c.samples = nil, nil, 0
+}
+
+// Box returns the interaction box enclosing the fingertip positions recorded.
+// As InteractionBox.Center holds whole millimeters, the box is grown by up to
+// a millimeter so it still encloses them. It returns ErrInvalidBox if nothing
+// has been recorded and ErrDegenerateBox if the positions don't span every
+// axis.
+func (c *Calibrator) Box() (InteractionBox, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.samples == 0 {
+		return InteractionBox{}, fmt.Errorf("%w: no fingertip position recorded", ErrInvalidBox)
+	}
+
+	box := InteractionBox{Center: make([]int, 3), Size: make([]float64, 3)}
+	for k := 0; k < 3; k++ {
+		if c.max[k] == c.min[k] {
+			return InteractionBox{}, ErrDegenerateBox
+		}
+
+		center := math.Round((c.min[k] + c.max[k]) / 2)
+		box.Center[k] = int(center)
+		box.Size[k] = 2 * math.Max(center-c.min[k], c.max[k]-center)
+	}
+	return box, nil
+}
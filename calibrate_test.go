@@ -0,0 +1,54 @@
+package leapmotion
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestCalibrator(t *testing.T) {
+	c := NewCalibrator()
+
+	if _, err := c.Box(); !errors.Is(err, ErrInvalidBox) {
+		t.Fatalf("Received %v. Expected ErrInvalidBox before any frame", err)
+	}
+
+	c.Update(&Frame{Pointables: []Pointable{
+		{TipPosition: []float64{-100, 100, -50}},
+		{TipPosition: []float64{500, 500, 500}, Tool: true},
+	}})
+	c.Update(&Frame{Pointables: []Pointable{{TipPosition: []float64{100, 300, 51}}}})
+
+	if n := c.Samples(); n != 2 {
+		t.Fatalf("Received %d samples. Expected 2", n)
+	}
+
+	box, err := c.Box()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if box.Center[0] != 0 || box.Center[1] != 200 || box.Center[2] != 1 {
+		t.Fatalf("Received center %v. Expected [0 200 1]", box.Center)
+	}
+	if box.Size[0] != 200 || box.Size[1] != 200 || box.Size[2] != 102 {
+		t.Fatalf("Received size %v. Expected [200 200 102]", box.Size)
+	}
+
+	// The recorded extremes normalize within [0..1]
+	for _, tip := range [][]float64{{-100, 100, -50}, {100, 300, 51}} {
+		n, err := box.NormalizePoint(tip, false)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, v := range n {
+			if v < 0 || v > 1 {
+				t.Fatalf("Received %v for %v. Expected values within [0..1]", n, tip)
+			}
+		}
+	}
+
+	c.Reset()
+	c.Update(&Frame{Pointables: []Pointable{{TipPosition: []float64{0, 200, 0}}}})
+	if _, err := c.Box(); !errors.Is(err, ErrDegenerateBox) {
+		t.Fatalf("Received %v. Expected ErrDegenerateBox for a single position", err)
+	}
+}
@@ -0,0 +1,108 @@
+package leapmotion
+
+// Clone returns a deep copy of the frame that shares no slices with it
+func (f *Frame) Clone() *Frame {
+	if f == nil {
+		return nil
+	}
+
+	c := *f
+	c.R = cloneMatrix(f.R)
+	c.T = cloneFloats(f.T)
+	c.InteractionBox = InteractionBox{
+		Center: cloneInts(f.InteractionBox.Center),
+		Size:   cloneFloats(f.InteractionBox.Size),
+	}
+
+	if f.Gestures != nil {
+		c.Gestures = make([]Gesture, len(f.Gestures))
+		for i := range f.Gestures {
+			c.Gestures[i] = f.Gestures[i].clone()
+		}
+	}
+	if f.Hands != nil {
+		c.Hands = make([]Hand, len(f.Hands))
+		for i := range f.Hands {
+			c.Hands[i] = f.Hands[i].clone()
+		}
+	}
+	if f.Pointables != nil {
+		c.Pointables = make([]Pointable, len(f.Pointables))
+		for i := range f.Pointables {
+			c.Pointables[i] = f.Pointables[i].clone()
+		}
+	}
+
+	return &c
+}
+
+func (g Gesture) clone() Gesture {
+	g.Center = cloneFloats(g.Center)
+	g.Direction = cloneFloats(g.Direction)
+	g.HandsIDs = cloneInts(g.HandsIDs)
+	g.Normal = cloneFloats(g.Normal)
+	g.PointableIDs = cloneInts(g.PointableIDs)
+	g.Position = cloneFloats(g.Position)
+	g.StartPosition = cloneFloats(g.StartPosition)
+	return g
+}
+
+func (h Hand) clone() Hand {
+	h.ArmBasis = cloneMatrix(h.ArmBasis)
+	h.Direction = cloneFloats(h.Direction)
+	h.Elbow = cloneFloats(h.Elbow)
+	h.PalmNormal = cloneFloats(h.PalmNormal)
+	h.PalmPosition = cloneFloats(h.PalmPosition)
+	h.PalmVelocity = cloneFloats(h.PalmVelocity)
+	h.R = cloneMatrix(h.R)
+	h.SphereCenter = cloneFloats(h.SphereCenter)
+	h.StabilizedPalmPosition = cloneFloats(h.StabilizedPalmPosition)
+	h.T = cloneFloats(h.T)
+	h.Wrist = cloneFloats(h.Wrist)
+	return h
+}
+
+func (p Pointable) clone() Pointable {
+	if p.Bases != nil {
+		bases := make([][][]float64, len(p.Bases))
+		for i := range p.Bases {
+			bases[i] = cloneMatrix(p.Bases[i])
+		}
+		p.Bases = bases
+	}
+	p.BtipPosition = cloneFloats(p.BtipPosition)
+	p.CarpPosition = cloneFloats(p.CarpPosition)
+	p.DipPosition = cloneFloats(p.DipPosition)
+	p.Direction = cloneFloats(p.Direction)
+	p.McpPosition = cloneFloats(p.McpPosition)
+	p.PipPosition = cloneFloats(p.PipPosition)
+	p.StabilizedTipPosition = cloneFloats(p.StabilizedTipPosition)
+	p.TipPosition = cloneFloats(p.TipPosition)
+	p.TipVelocity = cloneFloats(p.TipVelocity)
+	return p
+}
+
+func cloneFloats(s []float64) []float64 {
+	if s == nil {
+		return nil
+	}
+	return append([]float64(nil), s...)
+}
+
+func cloneInts(s []int) []int {
+	if s == nil {
+		return nil
+	}
+	return append([]int(nil), s...)
+}
+
+func cloneMatrix(m [][]float64) [][]float64 {
+	if m == nil {
+		return nil
+	}
+	c := make([][]float64, len(m))
+	for i := range m {
+		c[i] = cloneFloats(m[i])
+	}
+	return c
+}
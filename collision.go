@@ -0,0 +1,20 @@
+package leapmotion
+
+// TipWithin reports whether the tip of the pointable is inside the sphere of
+// radius millimeters around center, e.g. to hit test a virtual button
+func (p *Pointable) TipWithin(center []float64, radius float64) bool {
+	return within(p.TipPosition, center, radius)
+}
+
+// PalmWithin reports whether the center of the palm is inside the sphere of
+// radius millimeters around center
+func (h *Hand) PalmWithin(center []float64, radius float64) bool {
+	return within(h.PalmPosition, center, radius)
+}
+
+func within(position, center []float64, radius float64) bool {
+	if !isVector(position) || !isVector(center) || radius < 0 {
+		return false
+	}
+	return distanceSquared(position, center) <= radius*radius
+}
@@ -0,0 +1,30 @@
+package leapmotion
+
+import "testing"
+
+func TestWithin(t *testing.T) {
+	center := []float64{0, 200, 0}
+
+	tests := []struct {
+		position []float64
+		radius   float64
+		expected bool
+	}{
+		{[]float64{0, 200, 0}, 10, true},
+		{[]float64{6, 208, 0}, 10, true}, // exactly on the sphere
+		{[]float64{6, 209, 0}, 10, false},
+		{nil, 10, false},
+	}
+
+	for _, test := range tests {
+		p := Pointable{TipPosition: test.position}
+		if within := p.TipWithin(center, test.radius); within != test.expected {
+			t.Fatalf("Received %t for %f. Expected %t", within, test.position, test.expected)
+		}
+
+		h := Hand{PalmPosition: test.position}
+		if within := h.PalmWithin(center, test.radius); within != test.expected {
+			t.Fatalf("Received %t for %f. Expected %t", within, test.position, test.expected)
+		}
+	}
+}
@@ -0,0 +1,150 @@
+package leapmotion
+
+import (
+	"fmt"
+	"sort"
+	"time"
+)
+
+// ClientConfig is the effective configuration of a Client, for diagnostics
+type ClientConfig struct {
+	Address            string             // WebSocket URL of the service
+	Gestures           bool               // gesture recognition was requested
+	BackgroundMessages bool               // frames were requested while the app isn't focused
+	CustomDialer       bool               // WithDialer replaces the default WebSocket
+	Headers            []string           // names, not values, of the extra handshake headers
+	Subprotocols       []string           // requested WebSocket subprotocols
+	Fields             []string           // decoded top level fields; nil decodes all
+	Middleware         int                // number of middleware stages
+	BoxSmoothing       float64            // alpha of the smoothed interaction box; 0 is off
+	IdleFrames         time.Duration      // interval of synthetic idle frames; 0 is off
+	StrictDecode       bool               // malformed frames are dropped
+	Dedup              bool               // repeated frame IDs are dropped
+	MessageDecoder     bool               // messages are decoded before parsing
+	CustomUnmarshaler  bool               // JSON isn't decoded by encoding/json
+	SanityLimit        float64            // position limit of WithSanityCheck; 0 is off
+	MaxReceiveErrors   int                // consecutive read failures ending the connection
+	Workers            int                // goroutines running the frameHandler; 0 is the message loop
+	OrderedWorkers     bool               // worker handlers run one at a time in frame order
+	FrameHistory       int                // frames retained for History
+	GestureParams      map[string]float64 // parameters sent with SetGestureParam
+}
+
+// Config returns the configuration the Client is running with
+func (c *Client) Config() ClientConfig {
+	config := ClientConfig{
+		Address:            c.address,
+		Gestures:           !c.opts.noGestures,
+		BackgroundMessages: !c.opts.noBackground,
+		CustomDialer:       c.opts.dialer != nil,
+		Subprotocols:       append([]string(nil), c.opts.protocols...),
+		Middleware:         len(c.opts.middleware),
+		BoxSmoothing:       c.opts.boxAlpha,
+		IdleFrames:         c.opts.idle,
+		StrictDecode:       c.opts.strict,
+		Dedup:              c.opts.dedup,
+		MessageDecoder:     c.opts.decoder != nil,
+		CustomUnmarshaler:  c.opts.unmarshal != nil,
+		SanityLimit:        c.opts.sanityLimit,
+		MaxReceiveErrors:   c.maxReceiveErrors(),
+		Workers:            c.opts.workers,
+		OrderedWorkers:     c.opts.orderedWorkers,
+		FrameHistory:       c.history.size,
+	}
+
+	for key := range c.opts.header {
+		config.Headers = append(config.Headers, key)
+	}
+	sort.Strings(config.Headers)
+
+	for field := range c.opts.fields {
+		config.Fields = append(config.Fields, field)
+	}
+	sort.Strings(config.Fields)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	config.GestureParams = make(map[string]float64, len(c.gestureParams))
+	for key, value := range c.gestureParams {
+		config.GestureParams[key] = value
+	}
+
+	return config
+}
+
+// SetGestureParam tunes the gesture recognition of the Leap Motion service by
+// sending a configuration key and value, e.g.
+//
+//	c.SetGestureParam("Gesture.Swipe.MinLength", 200)
+func (c *Client) SetGestureParam(key string, value float64) error {
+	if key == "" {
+		return fmt.Errorf("%w: key isn't set", ErrInvalidConfig)
+	}
+
+	if err := c.sendConfig(key, map[string]float64{key: value}); err != nil {
+		return err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.gestureParams == nil {
+		c.gestureParams = make(map[string]float64)
+	}
+	c.gestureParams[key] = value
+
+	return nil
+}
+
+// SetServerFrameRate would ask the Leap Motion service to produce at most fps
+// frames per second. The WebSocket protocol has no message for this, so it
+// always returns ErrUnsupported without sending anything; throttle frames
+// client side instead, e.g. with a middleware stage.
+func (c *Client) SetServerFrameRate(fps int) error {
+	return fmt.Errorf("%w: the WebSocket protocol has no frame rate request", ErrUnsupported)
+}
+
+// configMessage is a message sent by one of the Set* helpers, replayed on a
+// new connection
+type configMessage struct {
+	key string
+	msg interface{}
+}
+
+// sendConfig sends msg and records it to be replayed after a reconnect. A
+// message sent for a key again replaces the earlier one and moves to the end
+// of the replay.
+func (c *Client) sendConfig(key string, msg interface{}) error {
+	if err := c.send(msg); err != nil {
+		return err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for i, m := range c.configs {
+		if m.key == key {
+			c.configs = append(c.configs[:i], c.configs[i+1:]...)
+			break
+		}
+	}
+	c.configs = append(c.configs, configMessage{key, msg})
+
+	return nil
+}
+
+// replayConfig resends the messages recorded by sendConfig, in the order they
+// were sent
+func (c *Client) replayConfig() error {
+	c.mu.Lock()
+	configs := append([]configMessage(nil), c.configs...)
+	c.mu.Unlock()
+
+	for _, m := range configs {
+		if err := c.send(m.msg); err != nil {
+			return err
+		}
+	}
+	return nil
+}
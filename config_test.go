@@ -0,0 +1,60 @@
+package leapmotion
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestConfig(t *testing.T) {
+	transport := newFakeTransport()
+
+	c, err := Connect(nil,
+		WithDialer(transport.dialer()),
+		WithHeader("Authorization", "Bearer token"),
+		WithFields("hands"),
+		WithIdleFrames(time.Second),
+		WithDedup(true),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer c.Close()
+
+	if err := c.SetGestureParam("Gesture.Swipe.MinLength", 200); err != nil {
+		t.Fatal(err)
+	}
+
+	config := c.Config()
+	if !config.Gestures || !config.BackgroundMessages || !config.CustomDialer || !config.Dedup || config.StrictDecode {
+		t.Fatalf("Received %+v. Expected the options to be applied", config)
+	}
+	if len(config.Headers) != 1 || config.Headers[0] != "Authorization" {
+		t.Fatalf("Received %v. Expected [Authorization]", config.Headers)
+	}
+	if len(config.Fields) != 3 || config.Fields[0] != "hands" {
+		t.Fatalf("Received %v. Expected [hands id timestamp]", config.Fields)
+	}
+	if config.IdleFrames != time.Second {
+		t.Fatalf("Received %v. Expected %v", config.IdleFrames, time.Second)
+	}
+	if config.GestureParams["Gesture.Swipe.MinLength"] != 200 {
+		t.Fatalf("Received %v. Expected Gesture.Swipe.MinLength 200", config.GestureParams)
+	}
+}
+
+func TestSetServerFrameRate(t *testing.T) {
+	transport := newFakeTransport()
+	c := newClient(nil, nil)
+	c.conn = transport
+
+	for _, version := range []string{"6", "7"} {
+		c.handleMessage([]byte(`{"serviceVersion": "2.3.1+33747", "version": ` + version + `}`))
+		if err := c.SetServerFrameRate(30); !errors.Is(err, ErrUnsupported) {
+			t.Fatalf("Received %v. Expected ErrUnsupported", err)
+		}
+	}
+	if sent := transport.sentMessages(); len(sent) != 0 {
+		t.Fatalf("Received %v. Expected nothing sent", sent)
+	}
+}
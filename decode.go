@@ -0,0 +1,159 @@
+package leapmotion
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+// unmarshal decodes data with the Unmarshaler set with WithUnmarshaler
+func (c *Client) unmarshal(data []byte, v interface{}) error {
+	if c.opts.unmarshal != nil {
+		return c.opts.unmarshal(data, v)
+	}
+	return json.Unmarshal(data, v)
+}
+
+// truncated reports whether raw is JSON cut short, as a message delivered
+// partially by a flaky network: its only syntax error is the end of the input
+func truncated(raw []byte) bool {
+	var syntax *json.SyntaxError
+	err := json.Unmarshal(raw, new(json.RawMessage))
+	return errors.As(err, &syntax) && syntax.Offset >= int64(len(raw))
+}
+
+// envelope holds every top level field a message can have, so a message is
+// decoded once whether it's a frame or one of the other messages
+type envelope struct {
+	*Frame
+	message
+}
+
+// decodeMessage decodes raw in a single pass into the message fields and, for
+// frames, the frame fields, limited to the fields set with WithFields. The
+// frame is only meaningful if the message turns out to be a frame.
+func (c *Client) decodeMessage(raw []byte) (*Frame, *message, error) {
+	e := envelope{Frame: &Frame{}}
+	if len(c.opts.fields) == 0 {
+		if err := c.unmarshal(raw, &e); err != nil {
+			return nil, nil, err
+		}
+		return e.Frame, &e.message, nil
+	}
+
+	var fields map[string]json.RawMessage
+	if err := c.unmarshal(raw, &fields); err != nil {
+		return nil, nil, err
+	}
+
+	for key, value := range fields {
+		target := e.message.field(key)
+		if target == nil && c.opts.fields[key] {
+			target = e.Frame.field(key)
+		}
+		if target == nil {
+			continue
+		}
+		if err := c.unmarshal(value, target); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	return e.Frame, &e.message, nil
+}
+
+// field returns a pointer to the field of the message with the JSON key, or
+// nil
+func (m *message) field(key string) interface{} {
+	switch key {
+	case "event":
+		return &m.Event
+	case "background":
+		return &m.Background
+	case "serviceVersion":
+		return &m.ServiceVersion
+	case "version":
+		return &m.Version
+	}
+	return nil
+}
+
+// field returns a pointer to the field of the frame with the JSON key, or nil
+func (f *Frame) field(key string) interface{} {
+	switch key {
+	case "currentFrameRate":
+		return &f.CurrentFrameRate
+	case "id":
+		return &f.ID
+	case "r":
+		return &f.R
+	case "s":
+		return &f.S
+	case "t":
+		return &f.T
+	case "timestamp":
+		return &f.Timestamp
+	case "gestures":
+		return &f.Gestures
+	case "hands":
+		return &f.Hands
+	case "interactionBox":
+		return &f.InteractionBox
+	case "pointables":
+		return &f.Pointables
+	}
+	return nil
+}
+
+// checkFrame reports the first vector the tracking data format requires that
+// is missing or has the wrong length in frame. Fields left out with WithFields
+// aren't checked.
+func (c *Client) checkFrame(frame *Frame) error {
+	check := func(name string, v []float64) error {
+		if len(v) != 3 {
+			return fmt.Errorf("frame %v: %w: %s has %d values, expected 3", frame.ID, ErrInvalidVector, name, len(v))
+		}
+		return nil
+	}
+	decoded := func(key string) bool {
+		return len(c.opts.fields) == 0 || c.opts.fields[key]
+	}
+
+	if decoded("interactionBox") {
+		if len(frame.InteractionBox.Center) != 3 {
+			return fmt.Errorf("frame %v: %w: interactionBox.center has %d values, expected 3", frame.ID, ErrInvalidVector, len(frame.InteractionBox.Center))
+		}
+		if err := check("interactionBox.size", frame.InteractionBox.Size); err != nil {
+			return err
+		}
+	}
+
+	if decoded("hands") {
+		for i, h := range frame.Hands {
+			prefix := fmt.Sprintf("hands[%d].", i)
+			if err := check(prefix+"palmPosition", h.PalmPosition); err != nil {
+				return err
+			}
+			if err := check(prefix+"palmNormal", h.PalmNormal); err != nil {
+				return err
+			}
+			if err := check(prefix+"direction", h.Direction); err != nil {
+				return err
+			}
+		}
+	}
+
+	if decoded("pointables") {
+		for i, p := range frame.Pointables {
+			prefix := fmt.Sprintf("pointables[%d].", i)
+			if err := check(prefix+"tipPosition", p.TipPosition); err != nil {
+				return err
+			}
+			if err := check(prefix+"direction", p.Direction); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
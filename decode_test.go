@@ -0,0 +1,186 @@
+package leapmotion
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"errors"
+	"io"
+	"testing"
+)
+
+// v6Frame follows the layout of the frames the v2.3.1 service sends, with one
+// hand and its index finger
+const v6Frame = `{"currentFrameRate":110.3,"devices":[],"gestures":[],"hands":[{"armBasis":[[0.982,-0.0247,-0.187],[0.0632,0.974,0.218],[0.177,-0.226,0.958]],"armWidth":61.4,"confidence":0.87,"direction":[0.176,0.291,-0.941],"elbow":[-39.6,111.3,285.8],"grabStrength":0,"id":68,"palmNormal":[-0.0812,-0.955,-0.286],"palmPosition":[14.1,176,45.2],"palmVelocity":[5.1,-12.4,3.3],"pinchStrength":0,"r":[[0.999,0.0184,-0.0322],[-0.0193,0.999,-0.0271],[0.0317,0.0277,0.999]],"s":1.02,"sphereCenter":[16.2,212.4,9.8],"sphereRadius":91.5,"stabilizedPalmPosition":[13.8,175.4,46.1],"t":[-4.2,2.5,11.9],"timeVisible":3.04,"type":"right","wrist":[8.3,153.9,99.4]}],"id":183020,"interactionBox":{"center":[0,200,0],"size":[235.2,235.2,147.7]},"pointables":[{"bases":[[[0.906,-0.0761,-0.416],[0.164,0.969,0.186],[0.39,-0.234,0.89]],[[0.927,-0.131,-0.351],[0.21,0.958,0.194],[0.311,-0.254,0.916]],[[0.929,-0.136,-0.344],[0.203,0.965,0.167],[0.309,-0.224,0.924]],[[0.929,-0.138,-0.343],[0.192,0.97,0.147],[0.312,-0.201,0.929]]],"btipPosition":[59.2,218.5,-52.8],"carpPosition":[23.9,174.9,75.3],"dipPosition":[56.6,215.7,-43.4],"direction":[0.343,0.167,-0.924],"extended":true,"handId":68,"id":681,"length":51.8,"mcpPosition":[36.7,200.6,10.6],"pipPosition":[48.3,208.4,-27.3],"stabilizedTipPosition":[58.4,217.2,-50.1],"timeVisible":3.04,"tipPosition":[58.4,217.6,-50.3],"tipVelocity":[9.4,-8.8,1.7],"tool":false,"touchDistance":0.33,"touchZone":"hovering","type":1,"width":16.8}],"r":[[1,0,0],[0,1,0],[0,0,1]],"s":1,"t":[0,0,0],"timestamp":49947752888}`
+
+func TestDecodeV6Frame(t *testing.T) {
+	var frame Frame
+	if err := json.Unmarshal([]byte(v6Frame), &frame); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(frame.Hands) != 1 || len(frame.Pointables) != 1 {
+		t.Fatalf("Received %d hands and %d pointables. Expected 1 and 1", len(frame.Hands), len(frame.Pointables))
+	}
+	basis := frame.Hands[0].ArmBasis
+	if len(basis) != 3 || len(basis[2]) != 3 || basis[2][2] != 0.958 {
+		t.Fatalf("Received %v. Expected a 3x3 arm basis", basis)
+	}
+	bases := frame.Pointables[0].Bases
+	if len(bases) != 4 || len(bases[3]) != 3 || bases[3][2][2] != 0.929 {
+		t.Fatalf("Received %v. Expected a 3x3 basis for each of the 4 bones", bases)
+	}
+	if frame.Pointables[0].Type != FingerIndex {
+		t.Fatalf("Received %v. Expected %v", frame.Pointables[0].Type, FingerIndex)
+	}
+}
+
+func TestDecodeFrameFields(t *testing.T) {
+	raw := []byte(`{"id": 7, "timestamp": 100, "hands": [{"id": 1}], "pointables": [{"id": 10}], "gestures": [{"id": 20}]}`)
+
+	c := &Client{}
+	WithFields("hands")(&c.opts)
+
+	frame, _, err := c.decodeMessage(raw)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if frame.ID != 7 || frame.Timestamp != 100 {
+		t.Fatalf("Received id %v and timestamp %d. Expected 7 and 100", frame.ID, frame.Timestamp)
+	}
+	if len(frame.Hands) != 1 {
+		t.Fatalf("Received %d hands. Expected 1", len(frame.Hands))
+	}
+	if frame.Pointables != nil || frame.Gestures != nil {
+		t.Fatal("Expected pointables and gestures not to be decoded")
+	}
+}
+
+func TestDecodeOnce(t *testing.T) {
+	tests := []struct {
+		opts     []Option
+		raw      string
+		expected int
+	}{
+		{nil, `{"id": 1, "hands": [{"id": 1}]}`, 1},
+		{nil, `{"serviceVersion": "2.3.1+33747", "version": 6}`, 1},
+		// The field map, then id and hands
+		{[]Option{WithFields("hands")}, `{"id": 1, "hands": [{"id": 1}], "pointables": []}`, 3},
+		{[]Option{WithFields()}, `{"event": {"state": {"attached": true, "id": "LP1"}, "type": "deviceEvent"}}`, 2},
+	}
+
+	for _, test := range tests {
+		calls := 0
+		c := newClient(nil, append(test.opts, WithUnmarshaler(func(data []byte, v interface{}) error {
+			calls++
+			return json.Unmarshal(data, v)
+		})))
+
+		c.handleMessage([]byte(test.raw))
+		if calls != test.expected {
+			t.Fatalf("Received %d Unmarshaler calls for %s. Expected %d", calls, test.raw, test.expected)
+		}
+	}
+
+	// Messages are still told apart from frames with WithFields
+	c := newClient(nil, []Option{WithFields()})
+	c.handleMessage([]byte(`{"serviceVersion": "2.3.1+33747", "version": 6}`))
+	c.handleMessage([]byte(`{"event": {"state": {"attached": true, "id": "LP1", "streaming": true}, "type": "deviceEvent"}}`))
+	if c.Handshake().Version != 6 || len(c.Devices()) != 1 || c.Stats().Frames != 0 {
+		t.Fatalf("Received handshake %v, devices %v and %d frames. Expected the messages not to be frames", c.Handshake(), c.Devices(), c.Stats().Frames)
+	}
+}
+
+func TestMessageDecoder(t *testing.T) {
+	var compressed bytes.Buffer
+	w := gzip.NewWriter(&compressed)
+	w.Write([]byte(`{"id": 3, "hands": [{"id": 1}]}`))
+	w.Close()
+
+	var received *Frame
+	c := newClient(func(frame *Frame) { received = frame }, []Option{
+		WithMessageDecoder(func(msg []byte) ([]byte, error) {
+			r, err := gzip.NewReader(bytes.NewReader(msg))
+			if err != nil {
+				return nil, err
+			}
+			return io.ReadAll(r)
+		}),
+	})
+
+	c.handleMessage(compressed.Bytes())
+
+	if received == nil || received.ID != 3 || len(received.Hands) != 1 {
+		t.Fatalf("Received %v. Expected the decompressed frame", received)
+	}
+}
+
+func TestStrictDecode(t *testing.T) {
+	received := 0
+	c := newClient(func(*Frame) { received++ }, []Option{WithStrictDecode(true)})
+
+	box := `"interactionBox": {"center": [0, 200, 0], "size": [200, 200, 200]}`
+	c.handleMessage([]byte(`{"id": 1, ` + box + `, "hands": [{"palmPosition": [0, 1, 2], "palmNormal": [0, -1, 0], "direction": [0, 0, -1]}]}`))
+	c.handleMessage([]byte(`{"id": 2, ` + box + `, "hands": [{"palmPosition": [0, 1], "palmNormal": [0, -1, 0], "direction": [0, 0, -1]}]}`))
+	c.handleMessage([]byte(`{"id": 3, "hands": [`))
+
+	if received != 1 {
+		t.Fatalf("Received %d frames. Expected only the well formed frame", received)
+	}
+
+	for i := 0; i < 2; i++ {
+		select {
+		case err := <-c.Errors():
+			if err == nil {
+				t.Fatal("Expected an error")
+			}
+		default:
+			t.Fatalf("Received %d errors. Expected 2", i)
+		}
+	}
+}
+
+func TestUnmarshaler(t *testing.T) {
+	calls := 0
+	c := newClient(nil, []Option{WithUnmarshaler(func(data []byte, v interface{}) error {
+		calls++
+		return json.Unmarshal(data, v)
+	})})
+
+	c.handleMessage([]byte(`{"id": 1}`))
+
+	if calls == 0 {
+		t.Fatal("Expected the frame to be decoded with the Unmarshaler")
+	}
+}
+
+func TestTruncatedMessage(t *testing.T) {
+	var ids []float64
+	c := newClient(func(frame *Frame) { ids = append(ids, frame.ID) }, nil)
+
+	c.handleMessage([]byte(`{"id": 1, "hands": [{"id": 1, "palmPosition": [0, 2`))
+	c.handleMessage([]byte(`{"id": 2, "hands": []}`))
+	c.handleMessage([]byte(`{"id": 3,, "hands": []}`)) // malformed, not truncated
+
+	if len(ids) != 1 || ids[0] != 2 {
+		t.Fatalf("Received frames %v. Expected only frame 2", ids)
+	}
+	if stats := c.Stats(); stats.Truncated != 1 {
+		t.Fatalf("Received %d truncated messages. Expected 1", stats.Truncated)
+	}
+
+	select {
+	case err := <-c.Errors():
+		if !errors.Is(err, ErrTruncatedMessage) {
+			t.Fatalf("Received %v. Expected ErrTruncatedMessage", err)
+		}
+	default:
+		t.Fatal("Expected the truncated message to be reported")
+	}
+	select {
+	case err := <-c.Errors():
+		t.Fatalf("Received %v. Expected the malformed message not to be reported without WithStrictDecode", err)
+	default:
+	}
+}
@@ -0,0 +1,69 @@
+package leapmotion
+
+import "sync"
+
+// frameGate skips a frame fed to a detector again, e.g. by several handlers
+// sharing the detector
+type frameGate struct {
+	started bool
+	frameID float64
+}
+
+// next reports whether frame is new to the gate and, if it is, records it as
+// the latest frame
+func (g *frameGate) next(frame *Frame) bool {
+	if frame == nil || (g.started && frame.ID == g.frameID) {
+		return false
+	}
+	g.started = true
+	g.frameID = frame.ID
+	return true
+}
+
+// handStates is the state a detector keeps per hand, keyed by hand ID
+type handStates[S any] map[int]S
+
+// get returns the state of the hand with id, created with init if the hand is
+// new
+func (h *handStates[S]) get(id int, init func() S) S {
+	st, ok := (*h)[id]
+	if !ok {
+		st = init()
+		h.set(id, st)
+	}
+	return st
+}
+
+// set stores the state of the hand with id
+func (h *handStates[S]) set(id int, st S) {
+	if *h == nil {
+		*h = make(handStates[S])
+	}
+	(*h)[id] = st
+}
+
+// forgetAbsent drops the states of the hands that aren't in frame, calling
+// left, if set, with each of them first
+func (h handStates[S]) forgetAbsent(frame *Frame, left func(id int, st S)) {
+	present := make(map[int]bool, len(frame.Hands))
+	for i := range frame.Hands {
+		present[frame.Hands[i].ID] = true
+	}
+
+	for id, st := range h {
+		if !present[id] {
+			if left != nil {
+				left(id, st)
+			}
+			delete(h, id)
+		}
+	}
+}
+
+// detector is the bookkeeping of the types fed frame by frame with Update:
+// the lock, the frame gate and a state of type S per hand
+type detector[S any] struct {
+	mu sync.Mutex
+	frameGate
+	hands handStates[S]
+}
@@ -0,0 +1,80 @@
+package leapmotion
+
+import (
+	"sort"
+	"testing"
+)
+
+// testFrame returns the frame with id at timestamp (microseconds) holding
+// hands, the frames the detector tests feed
+func testFrame(id, timestamp int, hands ...Hand) *Frame {
+	return &Frame{ID: float64(id), Timestamp: timestamp, Hands: hands}
+}
+
+func TestFrameGate(t *testing.T) {
+	var g frameGate
+
+	frames := []struct {
+		frame    *Frame
+		expected bool
+	}{
+		{nil, false},
+		{testFrame(0, 0), true},
+		{testFrame(0, 0), false},
+		{testFrame(1, 10000), true},
+		{testFrame(0, 20000), true},
+	}
+
+	for i, test := range frames {
+		if next := g.next(test.frame); next != test.expected {
+			t.Fatalf("Frame %d: received %t. Expected %t", i, next, test.expected)
+		}
+	}
+}
+
+func TestHandStates(t *testing.T) {
+	var hands handStates[*int]
+
+	created := 0
+	init := func() *int {
+		created++
+		n := created
+		return &n
+	}
+
+	frames := []struct {
+		frame     *Frame
+		created   int
+		forgotten []int
+	}{
+		{testFrame(1, 0, Hand{ID: 1}, Hand{ID: 2}), 2, nil},
+		// Known hands keep their state, hands that left are forgotten
+		{testFrame(2, 10000, Hand{ID: 2}, Hand{ID: 3}), 3, []int{1}},
+		{testFrame(3, 20000), 3, []int{2, 3}},
+	}
+
+	for _, test := range frames {
+		for _, hand := range test.frame.Hands {
+			hands.get(hand.ID, init)
+		}
+		var forgotten []int
+		hands.forgetAbsent(test.frame, func(id int, st *int) {
+			forgotten = append(forgotten, id)
+		})
+		sort.Ints(forgotten)
+
+		if created != test.created || len(hands) != len(test.frame.Hands) || len(forgotten) != len(test.forgotten) {
+			t.Fatalf("Frame %v: received %d created, %d hands and %v forgotten. Expected %d, %d and %v",
+				test.frame.ID, created, len(hands), forgotten, test.created, len(test.frame.Hands), test.forgotten)
+		}
+		for i := range forgotten {
+			if forgotten[i] != test.forgotten[i] {
+				t.Fatalf("Frame %v: received %v forgotten. Expected %v", test.frame.ID, forgotten, test.forgotten)
+			}
+		}
+	}
+
+	if st := hands.get(3, init); *st != 4 {
+		t.Fatalf("Received %d. Expected a new state for a forgotten hand", *st)
+	}
+}
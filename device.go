@@ -0,0 +1,118 @@
+package leapmotion
+
+import "sort"
+
+const deviceEventType = "deviceEvent"
+
+// message is the envelope of the messages other than frames that the Leap
+// Motion service sends: the handshake sent on connecting
+//
+//	{"serviceVersion": "2.3.1+33747", "version": 6}
+//
+// device events
+//
+//	{"event": {"state": {"attached": true, "id": "...", "streaming": true, "type": "peripheral"}, "type": "deviceEvent"}}
+//
+// and the reply to the background policy request
+//
+//	{"background": true}
+type message struct {
+	Event *struct {
+		State DeviceEvent `json:"state"`
+		Type  string      `json:"type"`
+	} `json:"event"`
+	Background *bool `json:"background"`
+	Handshake
+}
+
+// Handshake is the first message the Leap Motion service sends on a new
+// connection, telling the version of the service and of the protocol
+type Handshake struct {
+	ServiceVersion string `json:"serviceVersion"`
+	Version        int    `json:"version"`
+}
+
+// Handshake returns the handshake the Leap Motion service sent when the
+// client connected. It is empty until the handshake has been received.
+func (c *Client) Handshake() Handshake {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.handshake
+}
+
+func (c *Client) handleDeviceEvent(e *DeviceEvent) {
+	c.mu.Lock()
+	if c.devices == nil {
+		c.devices = make(map[string]DeviceEvent)
+	}
+	if e.Attached {
+		c.devices[e.ID] = *e
+	} else {
+		delete(c.devices, e.ID)
+	}
+
+	// The service streams while any of the attached controllers does
+	c.streaming = false
+	for _, d := range c.devices {
+		if d.Streaming {
+			c.streaming = true
+			break
+		}
+	}
+	c.mu.Unlock()
+
+	if c.opts.onDevice != nil {
+		c.opts.onDevice(e)
+	}
+}
+
+// ConnectDeviceEvents connects to the Leap Motion service only to pass device
+// events, such as a controller being plugged in or unplugged, to cb. Neither
+// gestures nor frames in the background are requested and frames are decoded
+// no further than their ID, to keep the load on the daemon and the client
+// minimal.
+func ConnectDeviceEvents(cb func(event *DeviceEvent), opts ...Option) (*Client, error) {
+	opts = append(opts, WithFields(), WithDeviceHandler(cb), WithGestures(false), WithBackgroundMessages(false))
+
+	return Connect(nil, opts...)
+}
+
+// Devices returns the latest event of every attached controller, ordered by
+// ID, telling whether each is streaming. Controllers are added and removed as
+// their attach and detach events arrive.
+func (c *Client) Devices() []DeviceEvent {
+	c.mu.Lock()
+	devices := make([]DeviceEvent, 0, len(c.devices))
+	for _, d := range c.devices {
+		devices = append(devices, d)
+	}
+	c.mu.Unlock()
+
+	sort.Slice(devices, func(i, j int) bool {
+		return devices[i].ID < devices[j].ID
+	})
+	return devices
+}
+
+// IsStreaming reports whether the Leap Motion service is sending tracking data.
+// It is false when the service has been paused by the user or no attached
+// controller is streaming, which tells that apart from there being no hands in
+// view. A frame arriving means the service streams again.
+func (c *Client) IsStreaming() bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.streaming
+}
+
+// BackgroundGranted reports whether the Leap Motion service granted the request
+// to receive frames while the application isn't focused. It can be denied by
+// OS level settings, in which case frames stop whenever the application loses
+// focus.
+func (c *Client) BackgroundGranted() bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.backgroundGranted
+}
@@ -0,0 +1,160 @@
+package leapmotion
+
+import (
+	"fmt"
+	"testing"
+	"time"
+)
+
+func TestDeviceEventStreaming(t *testing.T) {
+	c := &Client{}
+
+	messages := []struct {
+		raw       string
+		streaming bool
+	}{
+		{`{"event": {"state": {"attached": true, "id": "LP1", "streaming": true, "type": "peripheral"}, "type": "deviceEvent"}}`, true},
+		{`{"event": {"state": {"attached": true, "id": "LP1", "streaming": false, "type": "peripheral"}, "type": "deviceEvent"}}`, false},
+		{`{"id": 1, "timestamp": 10, "hands": []}`, true},
+		{`{"event": {"state": {"attached": false, "id": "LP1", "streaming": true, "type": "peripheral"}, "type": "deviceEvent"}}`, false},
+	}
+
+	for _, m := range messages {
+		c.handleMessage([]byte(m.raw))
+		if c.IsStreaming() != m.streaming {
+			t.Fatalf("Received streaming %t after %s. Expected %t", c.IsStreaming(), m.raw, m.streaming)
+		}
+	}
+}
+
+func TestDeviceEventStreamingTwoDevices(t *testing.T) {
+	c := newClient(nil, nil)
+
+	event := func(id string, attached, streaming bool) []byte {
+		return []byte(fmt.Sprintf(`{"event": {"state": {"attached": %t, "id": %q, "streaming": %t, "type": "peripheral"}, "type": "deviceEvent"}}`, attached, id, streaming))
+	}
+
+	messages := []struct {
+		raw       []byte
+		streaming bool
+	}{
+		{event("LP1", true, true), true},
+		{event("LP2", true, false), true},
+		// The idle controller leaving doesn't stop the streaming one
+		{event("LP2", false, false), true},
+		{event("LP2", true, true), true},
+		{event("LP1", false, false), true},
+		{event("LP2", true, false), false},
+	}
+
+	for _, m := range messages {
+		c.handleMessage(m.raw)
+		if c.IsStreaming() != m.streaming {
+			t.Fatalf("Received streaming %t after %s. Expected %t", c.IsStreaming(), m.raw, m.streaming)
+		}
+	}
+}
+
+func TestBackgroundGranted(t *testing.T) {
+	c := &Client{}
+
+	if c.BackgroundGranted() {
+		t.Fatal("Expected background not to be granted before the reply")
+	}
+
+	c.handleMessage([]byte(`{"background": true}`))
+	if !c.BackgroundGranted() {
+		t.Fatal("Expected background to be granted")
+	}
+
+	c.handleMessage([]byte(`{"background": false}`))
+	if c.BackgroundGranted() {
+		t.Fatal("Expected background to be denied")
+	}
+}
+
+func TestHandshakeIsNotAFrame(t *testing.T) {
+	var frames []*Frame
+	c := newClient(func(frame *Frame) { frames = append(frames, frame) }, nil)
+
+	c.handleMessage([]byte(`{"serviceVersion": "2.3.1+33747", "version": 6}`))
+	c.handleMessage([]byte(`{"id": 1, "timestamp": 10}`))
+
+	if len(frames) != 1 || frames[0].ID != 1 {
+		t.Fatalf("Received %d frames. Expected only the tracking frame", len(frames))
+	}
+	if h := c.Handshake(); h.ServiceVersion != "2.3.1+33747" || h.Version != 6 {
+		t.Fatalf("Received handshake %v. Expected 2.3.1+33747 version 6", h)
+	}
+}
+
+func TestConnectDeviceEvents(t *testing.T) {
+	transport := newFakeTransport(
+		`{"id": 1, "timestamp": 10, "hands": [{"id": 1}]}`,
+		`{"event": {"state": {"attached": true, "id": "LP1", "streaming": true, "type": "peripheral"}, "type": "deviceEvent"}}`,
+	)
+
+	events := make(chan *DeviceEvent, 1)
+	c, err := ConnectDeviceEvents(func(e *DeviceEvent) {
+		events <- e
+	}, WithDialer(transport.dialer()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer c.Close()
+
+	select {
+	case e := <-events:
+		if e.ID != "LP1" || !e.Attached {
+			t.Fatalf("Received %+v. Expected LP1 attached", e)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("Timed out waiting for a device event")
+	}
+
+	if sent := transport.sentMessages(); len(sent) != 0 {
+		t.Fatalf("Received %v. Expected no setup messages", sent)
+	}
+	if config := c.Config(); config.Gestures || config.BackgroundMessages {
+		t.Fatalf("Received %+v. Expected gestures and background off", config)
+	}
+}
+
+func TestDevices(t *testing.T) {
+	c := newClient(nil, nil)
+
+	event := func(id string, attached, streaming bool) []byte {
+		return []byte(fmt.Sprintf(`{"event": {"state": {"attached": %t, "id": %q, "streaming": %t, "type": "peripheral"}, "type": "deviceEvent"}}`, attached, id, streaming))
+	}
+
+	c.handleMessage(event("LP2", true, true))
+	c.handleMessage(event("LP1", true, false))
+	c.handleMessage(event("LP3", true, true))
+	c.handleMessage(event("LP3", false, false))
+
+	devices := c.Devices()
+	if len(devices) != 2 || devices[0].ID != "LP1" || devices[1].ID != "LP2" {
+		t.Fatalf("Received %v. Expected LP1 and LP2", devices)
+	}
+	if devices[0].Streaming || !devices[1].Streaming {
+		t.Fatalf("Received %v. Expected only LP2 streaming", devices)
+	}
+}
+
+func TestWithDeviceHandler(t *testing.T) {
+	var events []*DeviceEvent
+	var frames []*Frame
+	c := newClient(func(frame *Frame) { frames = append(frames, frame) },
+		[]Option{WithDeviceHandler(func(e *DeviceEvent) { events = append(events, e) })})
+
+	c.handleMessage([]byte(`{"event": {"state": {"attached": true, "id": "LP1", "streaming": true, "type": "peripheral"}, "type": "deviceEvent"}}`))
+	c.handleMessage([]byte(`{"id": 1, "timestamp": 10}`))
+	c.handleMessage([]byte(`{"event": {"state": {"attached": false, "id": "LP1", "streaming": false, "type": "peripheral"}, "type": "deviceEvent"}}`))
+
+	if len(events) != 2 || !events[0].Attached || events[1].Attached || events[1].ID != "LP1" {
+		t.Fatalf("Received %v. Expected LP1 attached then detached", events)
+	}
+	if len(frames) != 1 || frames[0].ID != 1 {
+		t.Fatalf("Received %d frames. Expected only the tracking frame", len(frames))
+	}
+}
@@ -0,0 +1,61 @@
+package leapmotion
+
+import "sync"
+
+// enterWatcher follows which hands are in the frames delivered by a Client
+// and fires the OnHandEnter callbacks for hands appearing
+type enterWatcher struct {
+	mu      sync.Mutex
+	cbs     []func(id int, entryNormalized []float64)
+	present map[int]bool // keyed by hand ID
+}
+
+func (w *enterWatcher) update(frame *Frame) {
+	type entry struct {
+		id         int
+		normalized []float64
+	}
+	var entries []entry
+
+	w.mu.Lock()
+	if len(w.cbs) == 0 {
+		w.mu.Unlock()
+		return
+	}
+	present := make(map[int]bool, len(frame.Hands))
+	for i := range frame.Hands {
+		h := &frame.Hands[i]
+		present[h.ID] = true
+		if w.present[h.ID] {
+			continue
+		}
+
+		normalized, err := frame.InteractionBox.NormalizePoint(h.PalmPosition, true)
+		if err != nil {
+			normalized = nil
+		}
+		entries = append(entries, entry{h.ID, normalized})
+	}
+	w.present = present
+	cbs := w.cbs
+	w.mu.Unlock()
+
+	for _, e := range entries {
+		for _, cb := range cbs {
+			cb(e.id, e.normalized)
+		}
+	}
+}
+
+// OnHandEnter registers cb to be called when a hand appears, with the palm
+// position of its first frame normalized against that frame's interaction box
+// as by NormalizePoint, clamped, so the edge it entered from can be classified:
+// e.g. an x near 1 is the right edge. Both are taken after the middleware, so
+// they agree with WithCoordinateFlip. entryNormalized is nil if the frame has
+// no interaction box or palm position.
+func (c *Client) OnHandEnter(cb func(id int, entryNormalized []float64)) {
+	c.enters.mu.Lock()
+	defer c.enters.mu.Unlock()
+
+	c.enters.cbs = append(c.enters.cbs, cb)
+}
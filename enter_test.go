@@ -0,0 +1,80 @@
+package leapmotion
+
+import (
+	"math"
+	"testing"
+)
+
+func TestOnHandEnter(t *testing.T) {
+	c := newClient(nil, nil)
+
+	var ids []int
+	var entries [][]float64
+	c.OnHandEnter(func(id int, entryNormalized []float64) {
+		ids = append(ids, id)
+		entries = append(entries, entryNormalized)
+	})
+
+	box := `"interactionBox": {"center": [0, 200, 0], "size": [200, 200, 200]}`
+	c.handleMessage([]byte(`{"id": 1, ` + box + `, "hands": [{"id": 3, "palmPosition": [90, 200, 0]}]}`))
+	c.handleMessage([]byte(`{"id": 2, ` + box + `, "hands": [{"id": 3, "palmPosition": [50, 200, 0]}]}`))
+	c.handleMessage([]byte(`{"id": 3, ` + box + `, "hands": [{"id": 3, "palmPosition": [0, 200, 0]}, {"id": 4, "palmPosition": [-150, 250, 0]}]}`))
+
+	if len(ids) != 2 || ids[0] != 3 || ids[1] != 4 {
+		t.Fatalf("Received entries of hands %v. Expected [3 4]", ids)
+	}
+	expected := [][]float64{{0.95, 0.5, 0.5}, {0, 0.75, 0.5}}
+	for i := range expected {
+		for k := range expected[i] {
+			if entries[i][k] != expected[i][k] {
+				t.Fatalf("Received %v. Expected %v", entries, expected)
+			}
+		}
+	}
+
+	// A hand reappearing enters again
+	c.handleMessage([]byte(`{"id": 4, ` + box + `, "hands": []}`))
+	c.handleMessage([]byte(`{"id": 5, ` + box + `, "hands": [{"id": 3, "palmPosition": [0, 200, 0]}]}`))
+	if len(ids) != 3 || ids[2] != 3 {
+		t.Fatalf("Received entries of hands %v. Expected [3 4 3]", ids)
+	}
+}
+
+func TestOnHandEnterWithCoordinateFlip(t *testing.T) {
+	c := newClient(nil, []Option{WithCoordinateFlip(AxisX, AxisY)})
+
+	var entry []float64
+	c.OnHandEnter(func(id int, entryNormalized []float64) {
+		entry = entryNormalized
+	})
+
+	// Normalized against the flipped box the palm stays inside it
+	box := `"interactionBox": {"center": [0, 200, 0], "size": [200, 200, 200]}`
+	c.handleMessage([]byte(`{"id": 1, ` + box + `, "hands": [{"id": 3, "palmPosition": [90, 250, 0]}]}`))
+
+	expected := []float64{0.05, 0.25, 0.5}
+	if len(entry) != 3 {
+		t.Fatalf("Received %v. Expected %v", entry, expected)
+	}
+	for k := range expected {
+		if math.Abs(entry[k]-expected[k]) > 1e-9 {
+			t.Fatalf("Received %v. Expected %v", entry, expected)
+		}
+	}
+}
+
+func TestOnHandEnterIgnoresIdleFrames(t *testing.T) {
+	c := newClient(nil, nil)
+
+	enters := 0
+	c.OnHandEnter(func(id int, entryNormalized []float64) {
+		enters++
+	})
+
+	c.handleMessage([]byte(`{"id": 1, "hands": [{"id": 3}]}`))
+	c.handleFrame(&Frame{Synthetic: true})
+	c.handleMessage([]byte(`{"id": 2, "hands": [{"id": 3}]}`))
+	if enters != 1 {
+		t.Fatalf("Received %d entries. Expected 1", enters)
+	}
+}
@@ -0,0 +1,84 @@
+package leapmotion
+
+import (
+	"math"
+	"reflect"
+)
+
+// DefaultEpsilon is the tolerance Frame.Equal compares floats with
+const DefaultEpsilon = 1e-6
+
+// EqualOption configures how Frame.Equal compares frames
+type EqualOption func(*equalConfig)
+
+type equalConfig struct {
+	epsilon float64
+	ignore  map[string]bool
+}
+
+// EqualEpsilon sets the tolerance floats are compared with
+func EqualEpsilon(epsilon float64) EqualOption {
+	return func(c *equalConfig) {
+		c.epsilon = epsilon
+	}
+}
+
+// EqualIgnoreFields skips the named struct fields, e.g. "ID" or "TimeVisible",
+// wherever they appear in the frame
+func EqualIgnoreFields(names ...string) EqualOption {
+	return func(c *equalConfig) {
+		for _, name := range names {
+			c.ignore[name] = true
+		}
+	}
+}
+
+// Equal reports whether two frames are structurally equal, comparing floats
+// within DefaultEpsilon. The volatile Timestamp, CurrentFrameRate and
+// ReceivedAt fields are ignored. A nil and an empty slice are equal.
+func (f *Frame) Equal(other *Frame, opts ...EqualOption) bool {
+	if f == nil || other == nil {
+		return f == other
+	}
+
+	c := &equalConfig{
+		epsilon: DefaultEpsilon,
+		ignore:  map[string]bool{"Timestamp": true, "CurrentFrameRate": true, "ReceivedAt": true},
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	return c.equal(reflect.ValueOf(f).Elem(), reflect.ValueOf(other).Elem())
+}
+
+func (c *equalConfig) equal(a, b reflect.Value) bool {
+	switch a.Kind() {
+	case reflect.Float32, reflect.Float64:
+		return math.Abs(a.Float()-b.Float()) <= c.epsilon
+	case reflect.Slice, reflect.Array:
+		if a.Len() != b.Len() {
+			return false
+		}
+		for i := 0; i < a.Len(); i++ {
+			if !c.equal(a.Index(i), b.Index(i)) {
+				return false
+			}
+		}
+		return true
+	case reflect.Struct:
+		t := a.Type()
+		for i := 0; i < t.NumField(); i++ {
+			field := t.Field(i)
+			if field.PkgPath != "" || c.ignore[field.Name] {
+				continue
+			}
+			if !c.equal(a.Field(i), b.Field(i)) {
+				return false
+			}
+		}
+		return true
+	default:
+		return a.Interface() == b.Interface()
+	}
+}
@@ -0,0 +1,31 @@
+package leapmotion
+
+import "testing"
+
+func TestFrameEqual(t *testing.T) {
+	a := &Frame{
+		ID:        1,
+		Timestamp: 100,
+		Hands:     []Hand{{ID: 1, PalmPosition: []float64{1, 2, 3}, Type: "left"}},
+	}
+
+	tests := []struct {
+		b        *Frame
+		opts     []EqualOption
+		expected bool
+	}{
+		// Timestamps and float noise are ignored
+		{&Frame{ID: 1, Timestamp: 200, Hands: []Hand{{ID: 1, PalmPosition: []float64{1, 2, 3.0000001}, Type: "left"}}}, nil, true},
+		{&Frame{ID: 1, Hands: []Hand{{ID: 1, PalmPosition: []float64{1, 2, 3.1}, Type: "left"}}}, nil, false},
+		{&Frame{ID: 1, Hands: []Hand{{ID: 1, PalmPosition: []float64{1, 2, 3.1}, Type: "left"}}}, []EqualOption{EqualEpsilon(0.5)}, true},
+		{&Frame{ID: 1, Hands: []Hand{{ID: 1, PalmPosition: []float64{1, 2, 3}, Type: "right"}}}, nil, false},
+		{&Frame{ID: 2, Hands: []Hand{{ID: 3, PalmPosition: []float64{1, 2, 3}, Type: "left"}}}, []EqualOption{EqualIgnoreFields("ID")}, true},
+		{&Frame{ID: 1}, nil, false},
+	}
+
+	for i, test := range tests {
+		if equal := a.Equal(test.b, test.opts...); equal != test.expected {
+			t.Fatalf("Test %d: received %t. Expected %t", i, equal, test.expected)
+		}
+	}
+}
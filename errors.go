@@ -0,0 +1,66 @@
+package leapmotion
+
+import "errors"
+
+// Errors returned by this package, possibly wrapped with more detail. Test
+// for them with errors.Is.
+var (
+	// ErrClosed is returned when using a Client after Close
+	ErrClosed = errors.New("client is closed")
+	// ErrNotConnected is returned when using a Client that was never connected
+	ErrNotConnected = errors.New("client isn't connected")
+	// ErrInvalidVector is returned for a position or direction that isn't set
+	// or doesn't have three values
+	ErrInvalidVector = errors.New("invalid vector")
+	// ErrInvalidMatrix is returned for a matrix that isn't 3x3
+	ErrInvalidMatrix = errors.New("invalid matrix")
+	// ErrInvalidBox is returned for an interaction box whose Center or Size
+	// isn't set or doesn't have three values
+	ErrInvalidBox = errors.New("invalid interaction box")
+	// ErrDegenerateBox is returned when normalizing against an interaction box
+	// with a zero dimension, which would produce Inf or NaN coordinates
+	ErrDegenerateBox = errors.New("interaction box has a zero dimension")
+	// ErrNotTap is returned for a gesture that isn't a keyTap or screenTap
+	ErrNotTap = errors.New("gesture isn't a tap")
+	// ErrInvalidAddress is returned by ConnectTo for an address that isn't a
+	// ws or wss URL
+	ErrInvalidAddress = errors.New("invalid address")
+	// ErrInvalidConfig is returned for a configuration value the client
+	// refuses to send
+	ErrInvalidConfig = errors.New("invalid configuration")
+	// ErrTruncatedMessage is reported for a message that is JSON cut short,
+	// which is dropped
+	ErrTruncatedMessage = errors.New("truncated message")
+	// ErrReceiveFailed is reported when reading from the connection failed
+	// too many times in a row, which ends the connection
+	ErrReceiveFailed = errors.New("receiving failed")
+	// ErrUnsupported is returned for a request the protocol version of the
+	// Leap Motion service doesn't support
+	ErrUnsupported = errors.New("unsupported by the service")
+)
+
+// The phases of setting up a connection, reported in a ConnectError
+const (
+	PhaseDial              = "dial"
+	PhaseEnableGestures    = "enableGestures"
+	PhaseBackgroundMessage = "backgroundMessage"
+	PhaseReplay            = "replay"
+	PhaseOnConnect         = "onConnect"
+)
+
+// ConnectError is returned by Connect when setting up the connection fails.
+// Phase tells whether dialing, sending one of the setup messages, replaying
+// the configuration of the Set* helpers or the onConnect callback failed.
+type ConnectError struct {
+	Phase string
+	Err   error
+}
+
+func (e *ConnectError) Error() string {
+	return e.Phase + ": " + e.Err.Error()
+}
+
+// Unwrap returns the underlying error
+func (e *ConnectError) Unwrap() error {
+	return e.Err
+}
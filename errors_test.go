@@ -0,0 +1,115 @@
+package leapmotion
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestSentinelErrors(t *testing.T) {
+	box := InteractionBox{Center: []int{0, 200, 0}, Size: []float64{200, 200, 200}}
+
+	if _, err := box.NormalizePoint([]float64{1}, false); !errors.Is(err, ErrInvalidVector) {
+		t.Fatalf("Received %v. Expected ErrInvalidVector", err)
+	}
+	if _, err := (&InteractionBox{}).NormalizePoint([]float64{1, 2, 3}, false); !errors.Is(err, ErrInvalidBox) {
+		t.Fatalf("Received %v. Expected ErrInvalidBox", err)
+	}
+	if _, err := (&Gesture{Type: "swipe"}).NormalizedTapPosition(&box, false); !errors.Is(err, ErrNotTap) {
+		t.Fatalf("Received %v. Expected ErrNotTap", err)
+	}
+
+	c := newClient(nil, nil)
+	if err := c.SetGestureParam("Gesture.Swipe.MinLength", 200); !errors.Is(err, ErrNotConnected) {
+		t.Fatalf("Received %v. Expected ErrNotConnected", err)
+	}
+	if err := c.SetGestureParam("", 200); !errors.Is(err, ErrInvalidConfig) {
+		t.Fatalf("Received %v. Expected ErrInvalidConfig", err)
+	}
+
+	c.Close()
+	if err := c.SetGestureParam("Gesture.Swipe.MinLength", 200); !errors.Is(err, ErrClosed) {
+		t.Fatalf("Received %v. Expected ErrClosed", err)
+	}
+}
+
+func TestErrorsChannel(t *testing.T) {
+	c := newClient(nil, nil)
+
+	// Nobody reads, reporting must not block
+	for i := 0; i < errorsBuffer+5; i++ {
+		c.reportError(fmt.Errorf("unread %d", i))
+	}
+
+	c.Close()
+	c.reportError(errors.New("after close"))
+
+	// The oldest errors are kept, those reported while full are dropped
+	n := 0
+	for err := range c.Errors() {
+		if expected := fmt.Sprintf("unread %d", n); err.Error() != expected {
+			t.Fatalf("Received %v. Expected %v", err, expected)
+		}
+		n++
+	}
+	if n != errorsBuffer {
+		t.Fatalf("Received %d errors. Expected the %d buffered", n, errorsBuffer)
+	}
+}
+
+// failingTransport is a fakeTransport whose Send fails with err for the
+// messages containing fail
+type failingTransport struct {
+	*fakeTransport
+	fail string
+	err  error
+}
+
+func (t failingTransport) Send(msg []byte) error {
+	if strings.Contains(string(msg), t.fail) {
+		return t.err
+	}
+	return t.fakeTransport.Send(msg)
+}
+
+func TestConnectError(t *testing.T) {
+	sendErr := errors.New("broken pipe")
+
+	tests := []struct {
+		fail  string
+		phase string
+	}{
+		{"enableGestures", PhaseEnableGestures},
+		{"backgroundMessage", PhaseBackgroundMessage},
+	}
+
+	for _, test := range tests {
+		transport := failingTransport{newFakeTransport(), test.fail, sendErr}
+		_, err := Connect(nil, WithDialer(func(string) (Transport, error) {
+			return transport, nil
+		}))
+
+		var ce *ConnectError
+		if !errors.As(err, &ce) || ce.Phase != test.phase {
+			t.Fatalf("Received %v. Expected a ConnectError in phase %s", err, test.phase)
+		}
+		if unwrapped := errors.Unwrap(err); unwrapped != sendErr {
+			t.Fatalf("Received %v. Expected %v", unwrapped, sendErr)
+		}
+		select {
+		case <-transport.closed:
+		default:
+			t.Fatalf("Expected the transport to be closed when %s fails", test.fail)
+		}
+	}
+
+	dialErr := errors.New("connection refused")
+	_, err := Connect(nil, WithDialer(func(string) (Transport, error) {
+		return nil, dialErr
+	}))
+	var ce *ConnectError
+	if !errors.As(err, &ce) || ce.Phase != PhaseDial || errors.Unwrap(err) != dialErr {
+		t.Fatalf("Received %v. Expected a ConnectError in phase %s", err, PhaseDial)
+	}
+}
@@ -0,0 +1,47 @@
+package leapmotion
+
+// The protocol features Supports knows of
+const (
+	// FeatureGestures is gesture recognition, requested with enableGestures
+	FeatureGestures = "gestures"
+	// FeatureBackground is receiving frames while the app isn't focused,
+	// requested with backgroundMessage
+	FeatureBackground = "background"
+	// FeatureHMD is tracking optimized for a sensor mounted on a head mounted
+	// display, requested with optimizeHMD
+	FeatureHMD = "hmd"
+	// FeatureDeviceEvents is device events reporting attached and streaming
+	// sensors
+	FeatureDeviceEvents = "deviceEvents"
+	// FeatureGestureTypes is enabling and tuning gestures per type with
+	// SetGestureParam
+	FeatureGestureTypes = "gestureTypes"
+	// FeatureFrameRate is asking the service for a frame rate. The WebSocket
+	// protocol has no such request, so it's never supported.
+	FeatureFrameRate = "frameRate"
+	// FeatureImages is the camera images. The WebSocket protocol doesn't
+	// stream images in any version, so it's never supported.
+	FeatureImages = "images"
+)
+
+// featureVersions is the first protocol version supporting each feature
+var featureVersions = map[string]int{
+	FeatureGestures:     1,
+	FeatureBackground:   4,
+	FeatureHMD:          6,
+	FeatureDeviceEvents: 6,
+	FeatureGestureTypes: 6,
+}
+
+// Supports reports whether the connected Leap Motion service supports
+// feature, one of the Feature constants, as inferred from the protocol version
+// of its handshake. It's false for every feature until the handshake has been
+// received, and for features it doesn't know.
+func (c *Client) Supports(feature string) bool {
+	version, ok := featureVersions[feature]
+	if !ok {
+		return false
+	}
+
+	return c.Handshake().Version >= version
+}
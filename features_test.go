@@ -0,0 +1,32 @@
+package leapmotion
+
+import "testing"
+
+func TestSupports(t *testing.T) {
+	c := newClient(nil, nil)
+	if c.Supports(FeatureGestures) {
+		t.Fatal("Expected no feature to be supported before the handshake")
+	}
+
+	c.handleMessage([]byte(`{"serviceVersion": "2.3.1+33747", "version": 6}`))
+
+	tests := []struct {
+		feature  string
+		expected bool
+	}{
+		{FeatureGestures, true},
+		{FeatureBackground, true},
+		{FeatureHMD, true},
+		{FeatureDeviceEvents, true},
+		{FeatureGestureTypes, true},
+		{FeatureFrameRate, false},
+		{FeatureImages, false},
+		{"teleportation", false},
+	}
+
+	for _, test := range tests {
+		if supported := c.Supports(test.feature); supported != test.expected {
+			t.Fatalf("Received %v for %s. Expected %v", supported, test.feature, test.expected)
+		}
+	}
+}
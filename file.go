@@ -0,0 +1,122 @@
+package leapmotion
+
+import (
+	"bufio"
+	"encoding/json"
+	"io"
+	"os"
+	"sync"
+	"time"
+)
+
+const maxRecordedMessage = 1 << 20
+
+// ConnectFile returns a Client that replays the frames recorded in the file at
+// path instead of connecting to the Leap Motion service. The file holds one
+// JSON message per line. Frames are handed to frameHandler at the pace they
+// were recorded, and Done is closed once the whole file has been replayed, so
+// a recording can stand in for a live sensor by swapping the constructor. The
+// file is closed once it has been replayed, or by Close.
+func ConnectFile(path string, frameHandler func(frame *Frame), opts ...Option) (*Client, error) {
+	opts = append(opts, WithDialer(func(string) (Transport, error) {
+		return openFileTransport(path)
+	}))
+
+	return Connect(frameHandler, opts...)
+}
+
+// fileTransport is a Transport reading recorded messages from a file
+type fileTransport struct {
+	file    *os.File
+	scanner *bufio.Scanner
+
+	closed    chan struct{}
+	closeOnce sync.Once
+
+	lastTimestamp int
+	started       bool
+}
+
+func openFileTransport(path string) (*fileTransport, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 64*1024), maxRecordedMessage)
+
+	return &fileTransport{
+		file:    file,
+		scanner: scanner,
+		closed:  make(chan struct{}),
+	}, nil
+}
+
+// Send drops the message, there is no service to configure
+func (t *fileTransport) Send(msg []byte) error {
+	return nil
+}
+
+// Receive returns the next recorded message once the time between it and the
+// previous frame has passed. It returns io.EOF, and closes the file, at the
+// end of the recording.
+func (t *fileTransport) Receive() ([]byte, error) {
+	for {
+		if !t.scanner.Scan() {
+			if err := t.scanner.Err(); err != nil {
+				return nil, err
+			}
+			t.Close()
+			return nil, io.EOF
+		}
+
+		msg := t.scanner.Bytes()
+		if len(msg) == 0 {
+			continue
+		}
+		msg = append([]byte(nil), msg...)
+
+		if err := t.wait(msg); err != nil {
+			return nil, err
+		}
+		return msg, nil
+	}
+}
+
+// wait sleeps for the time between the previous frame and the frame in msg
+func (t *fileTransport) wait(msg []byte) error {
+	var frame struct {
+		Timestamp *int `json:"timestamp"`
+	}
+	if err := json.Unmarshal(msg, &frame); err != nil || frame.Timestamp == nil {
+		return nil
+	}
+
+	delay := time.Duration(*frame.Timestamp-t.lastTimestamp) * time.Microsecond
+	started := t.started
+	t.started = true
+	t.lastTimestamp = *frame.Timestamp
+	if !started || delay <= 0 {
+		return nil
+	}
+
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+		return nil
+	case <-t.closed:
+		return io.EOF
+	}
+}
+
+func (t *fileTransport) Close() error {
+	var err error
+	t.closeOnce.Do(func() {
+		close(t.closed)
+		err = t.file.Close()
+	})
+	return err
+}
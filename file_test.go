@@ -0,0 +1,67 @@
+package leapmotion
+
+import (
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestConnectFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "recording.json")
+	recording := `{"serviceVersion": "2.3.1+33747", "version": 6}
+{"id": 1, "timestamp": 1000000}
+{"id": 2, "timestamp": 1010000}
+
+{"id": 3, "timestamp": 1020000}
+`
+	if err := os.WriteFile(path, []byte(recording), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	var ids []float64
+	c, err := ConnectFile(path, func(frame *Frame) {
+		ids = append(ids, frame.ID)
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer c.Close()
+
+	select {
+	case <-c.Done():
+	case <-time.After(time.Second):
+		t.Fatal("Timed out waiting for the recording to finish")
+	}
+
+	if len(ids) != 3 || ids[0] != 1 || ids[2] != 3 {
+		t.Fatalf("Received frames %v. Expected [1 2 3]", ids)
+	}
+}
+
+func TestFileTransportClosesAtEOF(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "recording.json")
+	if err := os.WriteFile(path, []byte(`{"id": 1}`+"\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	transport, err := openFileTransport(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := transport.Receive(); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := transport.Receive(); err != io.EOF {
+		t.Fatalf("Received %v. Expected io.EOF", err)
+	}
+
+	if _, err := transport.file.Read(make([]byte, 1)); !errors.Is(err, os.ErrClosed) {
+		t.Fatalf("Received %v. Expected the file to be closed at the end of the recording", err)
+	}
+	if err := transport.Close(); err != nil {
+		t.Fatalf("Received %v. Expected Close after the end to be a no-op", err)
+	}
+}
@@ -0,0 +1,88 @@
+package leapmotion
+
+// Axis is one of the axes of the Leap Motion coordinate system
+type Axis int
+
+// The axes of the Leap Motion coordinate system
+const (
+	AxisX Axis = iota
+	AxisY
+	AxisZ
+)
+
+// Flip negates the given axes of every position, direction and basis in the
+// frame, in place, and returns the frame. This mirrors the coordinate system,
+// e.g. Flip(AxisZ) for a sensor mounted facing the user rather than desk up.
+func (f *Frame) Flip(axes ...Axis) *Frame {
+	s := []float64{1, 1, 1}
+	for _, a := range axes {
+		if a >= AxisX && a <= AxisZ {
+			s[a] = -s[a]
+		}
+	}
+
+	flipMatrix(f.R, s)
+	flipVector(f.T, s)
+	if len(f.InteractionBox.Center) >= 3 {
+		for k := 0; k < 3; k++ {
+			f.InteractionBox.Center[k] *= int(s[k])
+		}
+	}
+
+	for i := range f.Gestures {
+		g := &f.Gestures[i]
+		flipVectors(s, g.Center, g.Direction, g.Normal, g.Position, g.StartPosition)
+	}
+
+	for i := range f.Hands {
+		h := &f.Hands[i]
+		flipVectors(s, h.Direction, h.Elbow, h.PalmNormal, h.PalmPosition, h.PalmVelocity,
+			h.SphereCenter, h.StabilizedPalmPosition, h.T, h.Wrist)
+		flipVectors(s, h.ArmBasis...)
+		flipMatrix(h.R, s)
+	}
+
+	for i := range f.Pointables {
+		p := &f.Pointables[i]
+		flipVectors(s, p.BtipPosition, p.CarpPosition, p.DipPosition, p.Direction, p.McpPosition,
+			p.PipPosition, p.StabilizedTipPosition, p.TipPosition, p.TipVelocity)
+		for _, basis := range p.Bases {
+			flipVectors(s, basis...)
+		}
+	}
+
+	return f
+}
+
+func flipVector(v []float64, s []float64) {
+	if !isVector(v) {
+		return
+	}
+	v[0] *= s[0]
+	v[1] *= s[1]
+	v[2] *= s[2]
+}
+
+func flipVectors(s []float64, vectors ...[]float64) {
+	for _, v := range vectors {
+		flipVector(v, s)
+	}
+}
+
+// flipMatrix conjugates the rotation matrix m by the reflection s so it
+// rotates the same way in the flipped coordinate system
+func flipMatrix(m [][]float64, s []float64) {
+	if len(m) < 3 {
+		return
+	}
+	for i := 0; i < 3; i++ {
+		if len(m[i]) < 3 {
+			return
+		}
+	}
+	for i := 0; i < 3; i++ {
+		for j := 0; j < 3; j++ {
+			m[i][j] *= s[i] * s[j]
+		}
+	}
+}
@@ -0,0 +1,41 @@
+package leapmotion
+
+import "testing"
+
+func TestFrameFlip(t *testing.T) {
+	frame := &Frame{
+		R: [][]float64{{1, 2, 3}, {4, 5, 6}, {7, 8, 9}},
+		Hands: []Hand{{
+			PalmPosition: []float64{10, 200, 30},
+			ArmBasis:     [][]float64{{1, 0, 0}, {0, 1, 0}, {0, 0, 1}},
+		}},
+		Pointables: []Pointable{{TipPosition: []float64{1, 2, 3}}},
+		InteractionBox: InteractionBox{
+			Center: []int{0, 200, 10},
+		},
+	}
+
+	frame.Flip(AxisZ)
+
+	if p := frame.Hands[0].PalmPosition; p[0] != 10 || p[1] != 200 || p[2] != -30 {
+		t.Fatalf("Received palm position %f. Expected [10 200 -30]", p)
+	}
+	if p := frame.Pointables[0].TipPosition; p[2] != -3 {
+		t.Fatalf("Received tip position %f. Expected [1 2 -3]", p)
+	}
+	if b := frame.Hands[0].ArmBasis[2]; b[2] != -1 {
+		t.Fatalf("Received arm basis %f. Expected [0 0 -1]", b)
+	}
+	if c := frame.InteractionBox.Center; c[2] != -10 {
+		t.Fatalf("Received center %d. Expected [0 200 -10]", c)
+	}
+
+	expected := [][]float64{{1, 2, -3}, {4, 5, -6}, {-7, -8, 9}}
+	for i := range expected {
+		for j := range expected[i] {
+			if frame.R[i][j] != expected[i][j] {
+				t.Fatalf("Received rotation %f. Expected %f", frame.R, expected)
+			}
+		}
+	}
+}
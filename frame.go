@@ -0,0 +1,197 @@
+package leapmotion
+
+import (
+	"fmt"
+	"strings"
+)
+
+// PrimaryHand returns the daemon's primary hand: the first hand of the frame,
+// as the Leap Motion service orders hands by tracking priority. It returns nil
+// if there are no hands in the frame.
+func (f *Frame) PrimaryHand() *Hand {
+	if len(f.Hands) == 0 {
+		return nil
+	}
+	return &f.Hands[0]
+}
+
+// LeftHand returns the left hand of the frame. If noisy tracking reports
+// several left hands, the one with the highest Confidence is returned. The
+// bool is false if there is no left hand.
+func (f *Frame) LeftHand() (*Hand, bool) {
+	return f.handOfType(HandLeft)
+}
+
+// RightHand returns the right hand of the frame like LeftHand
+func (f *Frame) RightHand() (*Hand, bool) {
+	return f.handOfType(HandRight)
+}
+
+// handOfType returns the most confident hand of type t, the first one on ties
+func (f *Frame) handOfType(t HandType) (*Hand, bool) {
+	var best *Hand
+	for i := range f.Hands {
+		h := &f.Hands[i]
+		if h.HandType() == t && (best == nil || h.Confidence > best.Confidence) {
+			best = h
+		}
+	}
+	return best, best != nil
+}
+
+// EstablishedHands returns the hands that have been tracked for at least
+// minVisible seconds. Tracking of a hand that has just entered the field of
+// view isn't stable yet.
+func (f *Frame) EstablishedHands(minVisible float64) []*Hand {
+	var hands []*Hand
+	for i := range f.Hands {
+		if f.Hands[i].TimeVisible >= minVisible {
+			hands = append(hands, &f.Hands[i])
+		}
+	}
+	return hands
+}
+
+// NumHands returns the number of hands in the frame. A nil Hands is valid and
+// means no hands are in view.
+func (f *Frame) NumHands() int {
+	return len(f.Hands)
+}
+
+// NumPointables returns the number of fingers and tools in the frame. A nil
+// Pointables is valid and means none are in view.
+func (f *Frame) NumPointables() int {
+	return len(f.Pointables)
+}
+
+// FingersForHand returns the fingers of the hand with handID, in the order of
+// f.Pointables. Tools aren't included. It's empty, not nil, if the hand has no
+// fingers tracked.
+func (f *Frame) FingersForHand(handID int) []Pointable {
+	fingers := []Pointable{}
+	for _, p := range f.Pointables {
+		if p.HandID == handID && !p.Tool {
+			fingers = append(fingers, p)
+		}
+	}
+	return fingers
+}
+
+// NumExtendedFingers returns the number of extended fingers, not counting
+// tools, in the frame
+func (f *Frame) NumExtendedFingers() int {
+	n := 0
+	for _, p := range f.Pointables {
+		if p.Extended && !p.Tool {
+			n++
+		}
+	}
+	return n
+}
+
+// PrimaryPointable returns the finger the user is most clearly pointing with:
+// the longest extended finger, not counting tools. Ties go to the finger whose
+// direction points furthest toward -Z, away from the user, then to the lowest
+// ID, so the choice is deterministic. It returns nil if no finger is extended.
+func (f *Frame) PrimaryPointable() *Pointable {
+	var primary *Pointable
+	for i := range f.Pointables {
+		p := &f.Pointables[i]
+		if !p.Extended || p.Tool {
+			continue
+		}
+		if primary == nil || pointsBetter(p, primary) {
+			primary = p
+		}
+	}
+	return primary
+}
+
+// pointsBetter reports whether p ranks above q for PrimaryPointable
+func pointsBetter(p, q *Pointable) bool {
+	if p.Length != q.Length {
+		return p.Length > q.Length
+	}
+
+	pz, qz := directionZ(p), directionZ(q)
+	if pz != qz {
+		return pz < qz
+	}
+
+	return p.ID < q.ID
+}
+
+func directionZ(p *Pointable) float64 {
+	if !isVector(p.Direction) {
+		return 0
+	}
+	return p.Direction[2]
+}
+
+// ActiveGestureIDs returns the IDs of the gestures in the frame, in the order
+// the service sent them. Gestures that stop in this frame are included, so
+// the IDs can be matched against gestures tracked in earlier frames.
+func (f *Frame) ActiveGestureIDs() []int {
+	ids := make([]int, 0, len(f.Gestures))
+	for _, g := range f.Gestures {
+		ids = append(ids, g.ID)
+	}
+	return ids
+}
+
+// Gesture returns the gesture of the frame with ID id, or nil if it isn't in
+// the frame
+func (f *Frame) Gesture(id int) *Gesture {
+	for i := range f.Gestures {
+		if f.Gestures[i].ID == id {
+			return &f.Gestures[i]
+		}
+	}
+	return nil
+}
+
+// String returns a one line summary of the frame for logs, e.g.
+//
+//	frame 1234 115.2fps hands 2 [L(-40.1 180.0 12.5) R(55.3 200.2 -3.0)] extended 6 gestures [swipe#7]
+//
+// Hands are L, R or ? for an unknown type, followed by the palm position.
+func (f *Frame) String() string {
+	if f == nil {
+		return "<nil>"
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "frame %.0f %.1ffps", f.ID, f.CurrentFrameRate)
+	if f.Synthetic {
+		b.WriteString(" synthetic")
+	}
+
+	fmt.Fprintf(&b, " hands %d [", len(f.Hands))
+	for i, h := range f.Hands {
+		if i > 0 {
+			b.WriteByte(' ')
+		}
+		switch h.HandType() {
+		case HandLeft:
+			b.WriteByte('L')
+		case HandRight:
+			b.WriteByte('R')
+		default:
+			b.WriteByte('?')
+		}
+		if isVector(h.PalmPosition) {
+			fmt.Fprintf(&b, "(%.1f %.1f %.1f)", h.PalmPosition[0], h.PalmPosition[1], h.PalmPosition[2])
+		}
+	}
+
+	fmt.Fprintf(&b, "] extended %d gestures [", f.NumExtendedFingers())
+	for i, g := range f.Gestures {
+		if i > 0 {
+			b.WriteByte(' ')
+		}
+		fmt.Fprintf(&b, "%s#%d", g.RawType(), g.ID)
+	}
+	b.WriteByte(']')
+
+	return b.String()
+}
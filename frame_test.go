@@ -0,0 +1,145 @@
+package leapmotion
+
+import "testing"
+
+func TestPrimaryHand(t *testing.T) {
+	if h := (&Frame{}).PrimaryHand(); h != nil {
+		t.Fatalf("Received %v. Expected nil for a frame without hands", h)
+	}
+
+	frame := &Frame{Hands: []Hand{{ID: 4}, {ID: 2}}}
+	if h := frame.PrimaryHand(); h == nil || h.ID != 4 {
+		t.Fatalf("Received %v. Expected the first hand", h)
+	}
+}
+
+func TestEstablishedHands(t *testing.T) {
+	frame := &Frame{Hands: []Hand{{ID: 1, TimeVisible: 0.05}, {ID: 2, TimeVisible: 1.5}}}
+
+	hands := frame.EstablishedHands(0.5)
+	if len(hands) != 1 || hands[0].ID != 2 {
+		t.Fatalf("Received %v. Expected only hand 2", hands)
+	}
+}
+
+func TestFrameCounts(t *testing.T) {
+	empty := &Frame{}
+	if empty.NumHands() != 0 || empty.NumPointables() != 0 || empty.NumExtendedFingers() != 0 {
+		t.Fatal("Expected no hands or pointables in an empty frame")
+	}
+
+	frame := &Frame{
+		Hands: []Hand{{ID: 1}},
+		Pointables: []Pointable{
+			{Extended: true},
+			{Extended: false},
+			{Extended: true, Tool: true},
+		},
+	}
+	if frame.NumHands() != 1 || frame.NumPointables() != 3 || frame.NumExtendedFingers() != 1 {
+		t.Fatalf("Received %d hands, %d pointables, %d extended fingers. Expected 1, 3, 1",
+			frame.NumHands(), frame.NumPointables(), frame.NumExtendedFingers())
+	}
+}
+
+func TestPrimaryPointable(t *testing.T) {
+	if p := (&Frame{}).PrimaryPointable(); p != nil {
+		t.Fatalf("Received %v. Expected nil for a frame without pointables", p)
+	}
+
+	tests := []struct {
+		pointables []Pointable
+		expected   int
+	}{
+		{[]Pointable{{ID: 1, Extended: true, Length: 50}, {ID: 2, Extended: true, Length: 60}}, 2},
+		{[]Pointable{{ID: 1, Extended: false, Length: 80}, {ID: 2, Extended: true, Length: 60}}, 2},
+		{[]Pointable{{ID: 1, Extended: true, Tool: true, Length: 120}, {ID: 2, Extended: true, Length: 60}}, 2},
+		{[]Pointable{
+			{ID: 1, Extended: true, Length: 60, Direction: []float64{0, 0, -0.5}},
+			{ID: 2, Extended: true, Length: 60, Direction: []float64{0, 0, -0.9}},
+		}, 2},
+		{[]Pointable{{ID: 3, Extended: true, Length: 60}, {ID: 2, Extended: true, Length: 60}}, 2},
+	}
+
+	for _, test := range tests {
+		frame := &Frame{Pointables: test.pointables}
+		if p := frame.PrimaryPointable(); p == nil || p.ID != test.expected {
+			t.Fatalf("Received %v. Expected pointable %d", p, test.expected)
+		}
+	}
+}
+
+func TestFrameGestures(t *testing.T) {
+	if ids := (&Frame{}).ActiveGestureIDs(); len(ids) != 0 {
+		t.Fatalf("Received %v. Expected no gesture IDs", ids)
+	}
+
+	frame := &Frame{Gestures: []Gesture{
+		{ID: 7, Type: "circle", State: "update"},
+		{ID: 3, Type: "swipe", State: "stop"},
+	}}
+
+	ids := frame.ActiveGestureIDs()
+	if len(ids) != 2 || ids[0] != 7 || ids[1] != 3 {
+		t.Fatalf("Received %v. Expected [7 3]", ids)
+	}
+
+	if g := frame.Gesture(3); g == nil || g.Type != "swipe" {
+		t.Fatalf("Received %v. Expected the swipe", g)
+	}
+	if g := frame.Gesture(1); g != nil {
+		t.Fatalf("Received %v. Expected nil for a gesture not in the frame", g)
+	}
+}
+
+func TestFrameString(t *testing.T) {
+	frame := &Frame{
+		ID:               1234,
+		CurrentFrameRate: 115.24,
+		Hands: []Hand{
+			{Type: "left", PalmPosition: []float64{-40.12, 180, 12.5}},
+			{Type: "right", PalmPosition: []float64{55.3, 200.2, -3}},
+		},
+		Pointables: []Pointable{{Extended: true}, {Extended: true}, {Extended: false}, {Extended: true, Tool: true}},
+		Gestures:   []Gesture{{ID: 7, Type: "swipe"}, {ID: 8, Type: "keyTap"}},
+	}
+
+	expected := "frame 1234 115.2fps hands 2 [L(-40.1 180.0 12.5) R(55.3 200.2 -3.0)] extended 2 gestures [swipe#7 keyTap#8]"
+	if s := frame.String(); s != expected {
+		t.Fatalf("Received %q. Expected %q", s, expected)
+	}
+
+	expected = "frame 0 0.0fps synthetic hands 0 [] extended 0 gestures []"
+	if s := (&Frame{Synthetic: true}).String(); s != expected {
+		t.Fatalf("Received %q. Expected %q", s, expected)
+	}
+
+	var nilFrame *Frame
+	if s := nilFrame.String(); s != "<nil>" {
+		t.Fatalf("Received %q. Expected <nil>", s)
+	}
+}
+
+func TestLeftRightHand(t *testing.T) {
+	frame := &Frame{Hands: []Hand{
+		{ID: 1, Type: "left", Confidence: 0.4},
+		{ID: 2, Type: "right", Confidence: 0.9},
+		{ID: 3, Type: "left", Confidence: 0.8},
+		{ID: 4, Type: "", Confidence: 1},
+	}}
+
+	if left, ok := frame.LeftHand(); !ok || left.ID != 3 {
+		t.Fatalf("Received %v, %t. Expected the more confident left hand 3", left, ok)
+	}
+	if right, ok := frame.RightHand(); !ok || right.ID != 2 {
+		t.Fatalf("Received %v, %t. Expected right hand 2", right, ok)
+	}
+	if frame.Hands[3].HandType() != HandUnknown {
+		t.Fatalf("Received %s. Expected %s for a hand without a type", frame.Hands[3].HandType(), HandUnknown)
+	}
+
+	empty := &Frame{Hands: []Hand{{ID: 1, Type: "left"}}}
+	if right, ok := empty.RightHand(); ok || right != nil {
+		t.Fatalf("Received %v, %t. Expected no right hand", right, ok)
+	}
+}
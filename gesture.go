@@ -0,0 +1,173 @@
+package leapmotion
+
+import (
+	"sync"
+	"time"
+)
+
+// GestureType is the type of a Gesture
+type GestureType string
+
+// The gesture types recognized by the Leap Motion service. Gestures of a type
+// this package doesn't know, e.g. from a newer service, are GestureUnknown.
+const (
+	GestureCircle    GestureType = "circle"
+	GestureSwipe     GestureType = "swipe"
+	GestureKeyTap    GestureType = "keyTap"
+	GestureScreenTap GestureType = "screenTap"
+	GestureUnknown   GestureType = "unknown"
+)
+
+// GestureType returns the type of the gesture, or GestureUnknown if the type
+// isn't recognized. RawType returns the type as sent by the service.
+func (g *Gesture) GestureType() GestureType {
+	switch t := GestureType(g.Type); t {
+	case GestureCircle, GestureSwipe, GestureKeyTap, GestureScreenTap:
+		return t
+	}
+	return GestureUnknown
+}
+
+// GestureState is the lifecycle state of a Gesture
+type GestureState string
+
+// The states of a gesture. Discrete gestures, such as taps, are only sent in
+// GestureStateStop. A state this package doesn't know, or a missing one, is
+// GestureStateUnknown.
+const (
+	GestureStateStart   GestureState = "start"
+	GestureStateUpdate  GestureState = "update"
+	GestureStateStop    GestureState = "stop"
+	GestureStateUnknown GestureState = "unknown"
+)
+
+// GestureState returns the state of the gesture, or GestureStateUnknown if
+// the state isn't recognized
+func (g *Gesture) GestureState() GestureState {
+	switch s := GestureState(g.State); s {
+	case GestureStateStart, GestureStateUpdate, GestureStateStop:
+		return s
+	}
+	return GestureStateUnknown
+}
+
+// RawType returns the type of the gesture exactly as sent by the Leap Motion
+// service, which is kept for gestures that are GestureUnknown
+func (g *Gesture) RawType() string {
+	return g.Type
+}
+
+// TapPosition returns where a keyTap or screenTap gesture occurred. The bool
+// is false for other gestures or if the position isn't set.
+func (g *Gesture) TapPosition() ([]float64, bool) {
+	switch g.GestureType() {
+	case GestureKeyTap, GestureScreenTap:
+	default:
+		return nil, false
+	}
+	if !isVector(g.Position) {
+		return nil, false
+	}
+
+	return []float64{g.Position[0], g.Position[1], g.Position[2]}, true
+}
+
+// NormalizedTapPosition returns where a keyTap or screenTap gesture occurred,
+// normalized with the interaction box as by NormalizePoint. It returns
+// ErrNotTap for other gestures and gestures without a position.
+func (g *Gesture) NormalizedTapPosition(box *InteractionBox, clamp bool) ([]float64, error) {
+	position, ok := g.TapPosition()
+	if !ok {
+		return nil, ErrNotTap
+	}
+
+	return box.NormalizePoint(position, clamp)
+}
+
+// IsDeliberateCircle reports whether the gesture is a circle drawn on purpose
+// rather than fidgeting: at least one full turn, of at least minRadius
+// millimeters, at a speed in millimeters per second between minSpeed and
+// maxSpeed
+func (g *Gesture) IsDeliberateCircle(minRadius, minSpeed, maxSpeed float64) bool {
+	return g.GestureType() == GestureCircle &&
+		g.Progress >= 1 &&
+		g.Radius >= minRadius &&
+		g.Speed >= minSpeed && g.Speed <= maxSpeed
+}
+
+// GestureTracker reports every gesture once, when it first appears, instead of
+// in each frame it is updated in. Once a gesture of a type fires, further
+// gestures of that type are suppressed for Cooldown, since the service often
+// emits near duplicates of a keyTap or swipe in the following frames. Feed it
+// every frame with Update.
+type GestureTracker struct {
+	// Cooldown is how long after a gesture fires new gestures of its type
+	// are suppressed. 0 disables the cooldown.
+	Cooldown time.Duration
+
+	mu sync.Mutex
+	frameGate
+	onGesture func(Gesture)
+	seen      map[int]bool        // IDs of the gestures of the last frame
+	fired     map[GestureType]int // frame timestamp (microseconds) a type last fired at
+}
+
+// NewGestureTracker returns a GestureTracker suppressing gestures of a type
+// for cooldown after one fires
+func NewGestureTracker(cooldown time.Duration) *GestureTracker {
+	return &GestureTracker{
+		Cooldown: cooldown,
+		seen:     make(map[int]bool),
+		fired:    make(map[GestureType]int),
+	}
+}
+
+// OnGesture registers cb to be called with every gesture that fires
+func (t *GestureTracker) OnGesture(cb func(Gesture)) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.onGesture = cb
+}
+
+// Update feeds frame to the tracker, calling the OnGesture callback for every
+// gesture appearing in it that isn't within the cooldown of its type.
+// Suppressed gestures stay suppressed for the rest of their lifetime.
+func (t *GestureTracker) Update(frame *Frame) {
+	var fired []Gesture
+
+	t.mu.Lock()
+	if !t.next(frame) {
+		t.mu.Unlock()
+		return
+	}
+	if t.seen == nil {
+		t.seen = make(map[int]bool)
+		t.fired = make(map[GestureType]int)
+	}
+
+	cooldown := int(t.Cooldown / time.Microsecond)
+	seen := make(map[int]bool, len(frame.Gestures))
+	for _, g := range frame.Gestures {
+		seen[g.ID] = true
+		if t.seen[g.ID] {
+			continue
+		}
+
+		typ := g.GestureType()
+		if last, ok := t.fired[typ]; ok && frame.Timestamp-last < cooldown {
+			continue
+		}
+		t.fired[typ] = frame.Timestamp
+		fired = append(fired, g)
+	}
+	t.seen = seen
+	cb := t.onGesture
+	t.mu.Unlock()
+
+	if cb != nil {
+		for _, g := range fired {
+			cb(g)
+		}
+	}
+}
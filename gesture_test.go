@@ -0,0 +1,134 @@
+package leapmotion
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+func TestGestureType(t *testing.T) {
+	tests := []struct {
+		raw      string
+		expected GestureType
+	}{
+		{"circle", GestureCircle},
+		{"swipe", GestureSwipe},
+		{"keyTap", GestureKeyTap},
+		{"screenTap", GestureScreenTap},
+		{"pinch", GestureUnknown},
+		{"", GestureUnknown},
+	}
+
+	for _, test := range tests {
+		g := Gesture{Type: test.raw}
+		if g.GestureType() != test.expected {
+			t.Fatalf("Received %s for %q. Expected %s", g.GestureType(), test.raw, test.expected)
+		}
+		if g.RawType() != test.raw {
+			t.Fatalf("Received raw type %q. Expected %q", g.RawType(), test.raw)
+		}
+	}
+}
+
+func TestGestureState(t *testing.T) {
+	tests := []struct {
+		raw      string
+		expected GestureState
+	}{
+		{`"start"`, GestureStateStart},
+		{`"update"`, GestureStateUpdate},
+		{`"stop"`, GestureStateStop},
+		{`"paused"`, GestureStateUnknown},
+		{`""`, GestureStateUnknown},
+	}
+
+	for _, test := range tests {
+		var g Gesture
+		if err := json.Unmarshal([]byte(`{"id": 1, "state": `+test.raw+`}`), &g); err != nil {
+			t.Fatal(err)
+		}
+		if g.GestureState() != test.expected {
+			t.Fatalf("Received %s for %s. Expected %s", g.GestureState(), test.raw, test.expected)
+		}
+	}
+
+	if g := (Gesture{}); g.GestureState() != GestureStateUnknown {
+		t.Fatalf("Received %s for a missing state. Expected %s", g.GestureState(), GestureStateUnknown)
+	}
+}
+
+func TestNormalizedTapPosition(t *testing.T) {
+	box := &InteractionBox{
+		Center: []int{0, 200, 0},
+		Size:   []float64{200, 200, 200},
+	}
+
+	tap := Gesture{Type: "screenTap", Position: []float64{50, 250, 0}}
+	position, err := tap.NormalizedTapPosition(box, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if position[0] != 0.75 || position[1] != 0.75 || position[2] != 0.5 {
+		t.Fatalf("Received %f. Expected [0.75 0.75 0.5]", position)
+	}
+
+	swipe := Gesture{Type: "swipe", Position: []float64{50, 250, 0}}
+	if _, ok := swipe.TapPosition(); ok {
+		t.Fatal("Expected a swipe not to have a tap position")
+	}
+	if _, err := swipe.NormalizedTapPosition(box, true); err == nil {
+		t.Fatal("Expected an error for a swipe")
+	}
+}
+
+func TestIsDeliberateCircle(t *testing.T) {
+	tests := []struct {
+		gesture  Gesture
+		expected bool
+	}{
+		{Gesture{Type: "circle", Progress: 1.2, Radius: 40, Speed: 300}, true},
+		{Gesture{Type: "circle", Progress: 0.4, Radius: 40, Speed: 300}, false},
+		{Gesture{Type: "circle", Progress: 1.2, Radius: 10, Speed: 300}, false},
+		{Gesture{Type: "circle", Progress: 1.2, Radius: 40, Speed: 50}, false},
+		{Gesture{Type: "circle", Progress: 1.2, Radius: 40, Speed: 2000}, false},
+		{Gesture{Type: "swipe", Progress: 1.2, Radius: 40, Speed: 300}, false},
+	}
+
+	for i, test := range tests {
+		if deliberate := test.gesture.IsDeliberateCircle(20, 100, 1000); deliberate != test.expected {
+			t.Fatalf("Test %d: received %t. Expected %t", i, deliberate, test.expected)
+		}
+	}
+}
+
+func TestGestureTrackerCooldown(t *testing.T) {
+	tr := NewGestureTracker(500 * time.Millisecond)
+
+	var fired []int
+	tr.OnGesture(func(g Gesture) { fired = append(fired, g.ID) })
+
+	frame := func(id, timestamp int, gestures ...Gesture) *Frame {
+		f := testFrame(id, timestamp)
+		f.Gestures = gestures
+		return f
+	}
+	swipe := func(id int) Gesture { return Gesture{ID: id, Type: "swipe"} }
+	tap := func(id int) Gesture { return Gesture{ID: id, Type: "keyTap"} }
+
+	tr.Update(frame(1, 0, swipe(1)))
+	tr.Update(frame(2, 100000, swipe(1)))           // updated, not new
+	tr.Update(frame(3, 200000, swipe(1), swipe(2))) // duplicate within the cooldown
+	tr.Update(frame(4, 300000, swipe(2), tap(3)))   // other types aren't suppressed
+	tr.Update(frame(5, 600000, swipe(2), swipe(4))) // swipe 2 stays suppressed
+	tr.Update(frame(5, 600000, swipe(2), swipe(4))) // repeated frame
+
+	expected := []int{1, 3, 4}
+	if len(fired) != len(expected) {
+		t.Fatalf("Received %v. Expected %v", fired, expected)
+	}
+	for i := range expected {
+		if fired[i] != expected[i] {
+			t.Fatalf("Received %v. Expected %v", fired, expected)
+		}
+	}
+}
@@ -0,0 +1,119 @@
+package leapmotion
+
+import (
+	"sync"
+	"time"
+)
+
+// ClenchStrength is the grab strength a hand must hold for OnClench to count
+// it as a clenched fist
+const ClenchStrength = 0.95
+
+// grabWatcher is the grab state machine of a Client: it follows the grab
+// strength of every hand across frames and fires the registered callbacks on
+// transitions
+type grabWatcher struct {
+	mu       sync.Mutex
+	releases []*releaseWatch
+	clenches []*clenchWatch
+}
+
+type releaseWatch struct {
+	threshold float64
+	cb        func(handID int, velocity []float64)
+	grabbing  handStates[bool]
+}
+
+type clenchWatch struct {
+	hold  time.Duration
+	cb    func(handID int)
+	hands handStates[*clench]
+}
+
+type clench struct {
+	since int // frame timestamp (microseconds) the clench started at
+	fired bool
+}
+
+func (w *grabWatcher) update(frame *Frame) {
+	type release struct {
+		cb       func(int, []float64)
+		handID   int
+		velocity []float64
+	}
+	var fired []release
+	var clenched []func()
+
+	w.mu.Lock()
+	for _, r := range w.releases {
+		for i := range frame.Hands {
+			h := &frame.Hands[i]
+
+			grabbing := h.GrabStrength >= r.threshold
+			if r.grabbing[h.ID] && !grabbing {
+				// The velocity of the frame the grab ends in, the
+				// moment the object is let go
+				fired = append(fired, release{r.cb, h.ID, copyVector(h.PalmVelocity)})
+			}
+			r.grabbing.set(h.ID, grabbing)
+		}
+
+		// A hand that vanished didn't release anything
+		r.grabbing.forgetAbsent(frame, nil)
+	}
+
+	for _, cw := range w.clenches {
+		hold := int(cw.hold / time.Microsecond)
+		for i := range frame.Hands {
+			h := &frame.Hands[i]
+
+			if h.GrabStrength < ClenchStrength {
+				delete(cw.hands, h.ID)
+				continue
+			}
+
+			cl := cw.hands.get(h.ID, func() *clench { return &clench{since: frame.Timestamp} })
+			if !cl.fired && frame.Timestamp-cl.since >= hold {
+				cl.fired = true
+				cb, id := cw.cb, h.ID
+				clenched = append(clenched, func() { cb(id) })
+			}
+		}
+
+		cw.hands.forgetAbsent(frame, nil)
+	}
+	w.mu.Unlock()
+
+	for _, f := range fired {
+		f.cb(f.handID, f.velocity)
+	}
+	for _, f := range clenched {
+		f()
+	}
+}
+
+// OnRelease registers cb to be called when the grab strength of a hand drops
+// below threshold, e.g. to throw a grabbed object. cb is given the palm
+// velocity of the frame the grab ended in.
+func (c *Client) OnRelease(threshold float64, cb func(handID int, velocity []float64)) {
+	c.grabs.mu.Lock()
+	defer c.grabs.mu.Unlock()
+
+	c.grabs.releases = append(c.grabs.releases, &releaseWatch{
+		threshold: threshold,
+		cb:        cb,
+	})
+}
+
+// OnClench registers cb to be called when a hand clenches into a fist: its
+// grab strength stays at ClenchStrength or above for hold. A light or partial
+// grab doesn't fire. cb is called once per clench.
+func (c *Client) OnClench(hold time.Duration, cb func(handID int)) {
+	c.grabs.mu.Lock()
+	defer c.grabs.mu.Unlock()
+
+	c.grabs.clenches = append(c.grabs.clenches, &clenchWatch{
+		hold: hold,
+		cb:   cb,
+	})
+}
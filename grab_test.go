@@ -0,0 +1,74 @@
+package leapmotion
+
+import (
+	"fmt"
+	"testing"
+	"time"
+)
+
+func TestOnRelease(t *testing.T) {
+	c := newClient(nil, nil)
+
+	var releasedID int
+	var velocity []float64
+	c.OnRelease(0.8, func(handID int, v []float64) {
+		releasedID = handID
+		velocity = v
+	})
+
+	c.handleMessage([]byte(`{"id": 1, "hands": [{"id": 3, "grabStrength": 0.2, "palmVelocity": [0, 0, 0]}]}`))
+	c.handleMessage([]byte(`{"id": 2, "hands": [{"id": 3, "grabStrength": 1, "palmVelocity": [10, 0, 0]}]}`))
+	if velocity != nil {
+		t.Fatal("Expected no release while grabbing")
+	}
+
+	c.handleMessage([]byte(`{"id": 3, "hands": [{"id": 3, "grabStrength": 0.5, "palmVelocity": [500, 100, 0]}]}`))
+	if releasedID != 3 || velocity == nil || velocity[0] != 500 {
+		t.Fatalf("Received release of hand %d with velocity %f. Expected hand 3 with [500 100 0]", releasedID, velocity)
+	}
+
+	velocity = nil
+	c.handleMessage([]byte(`{"id": 4, "hands": [{"id": 3, "grabStrength": 0.1, "palmVelocity": [400, 0, 0]}]}`))
+	if velocity != nil {
+		t.Fatal("Expected a single release")
+	}
+}
+
+func TestOnReleaseAcrossIdleFrames(t *testing.T) {
+	c := newClient(nil, nil)
+
+	releases := 0
+	c.OnRelease(0.8, func(handID int, v []float64) {
+		releases++
+	})
+
+	// A stall while grabbing neither releases nor forgets the grab
+	c.handleMessage([]byte(`{"id": 1, "hands": [{"id": 3, "grabStrength": 1}]}`))
+	c.handleFrame(&Frame{Synthetic: true})
+	if releases != 0 {
+		t.Fatal("Expected no release on an idle frame")
+	}
+	c.handleMessage([]byte(`{"id": 2, "hands": [{"id": 3, "grabStrength": 0.2}]}`))
+	if releases != 1 {
+		t.Fatalf("Received %d releases. Expected 1", releases)
+	}
+}
+
+func TestOnClench(t *testing.T) {
+	c := newClient(nil, nil)
+
+	clenches := 0
+	c.OnClench(100*time.Millisecond, func(handID int) {
+		clenches++
+	})
+
+	strengths := []string{"0.7", "1", "1", "0.98", "1", "1", "0.5", "1"}
+	for i, strength := range strengths {
+		c.handleMessage([]byte(fmt.Sprintf(`{"id": %d, "timestamp": %d, "hands": [{"id": 3, "grabStrength": %s}]}`, i, i*40000, strength)))
+	}
+
+	// The clench held from 40ms to 200ms fires once, the last one isn't held
+	if clenches != 1 {
+		t.Fatalf("Received %d clenches. Expected 1", clenches)
+	}
+}
@@ -0,0 +1,157 @@
+package leapmotion
+
+import (
+	"math"
+	"sort"
+)
+
+// FingerType is the anatomical type of a finger, as reported in Pointable.Type
+type FingerType int
+
+// The finger types, in the order the Leap Motion service numbers them
+const (
+	FingerThumb FingerType = iota
+	FingerIndex
+	FingerMiddle
+	FingerRing
+	FingerPinky
+)
+
+// FingerUnknown is the type of a pointable whose finger type the service
+// didn't tell
+const FingerUnknown FingerType = -1
+
+// HandType tells whether a Hand is a left or a right hand
+type HandType string
+
+// The hand types, as sent in Hand.Type. A hand of a type the service didn't
+// tell is HandUnknown.
+const (
+	HandLeft    HandType = "left"
+	HandRight   HandType = "right"
+	HandUnknown HandType = "unknown"
+)
+
+// HandType returns whether the hand is a left or a right hand, or HandUnknown
+func (h *Hand) HandType() HandType {
+	switch t := HandType(h.Type); t {
+	case HandLeft, HandRight:
+		return t
+	}
+	return HandUnknown
+}
+
+// FacingSensor reports whether the palm or the fingers of the hand point
+// toward the sensor, along -Z, within toleranceDegrees: the angle between
+// PalmNormal or Direction and -Z is at most toleranceDegrees
+func (h *Hand) FacingSensor(toleranceDegrees float64) bool {
+	minCos := math.Cos(toleranceDegrees * math.Pi / 180)
+	for _, v := range [][]float64{h.PalmNormal, h.Direction} {
+		if !isVector(v) {
+			continue
+		}
+		if u := normalize(v); u != nil && -u[2] >= minCos {
+			return true
+		}
+	}
+	return false
+}
+
+// Fingers returns the fingers of the hand tracked in frame, in the order of
+// frame.Pointables. Tools aren't included. It's empty, not nil, if none is.
+func (h *Hand) Fingers(frame *Frame) []Pointable {
+	return frame.FingersForHand(h.ID)
+}
+
+// Finger returns the finger of type t belonging to the hand, or nil if it isn't
+// tracked in frame
+func (h *Hand) Finger(frame *Frame, t FingerType) *Pointable {
+	for i := range frame.Pointables {
+		p := &frame.Pointables[i]
+		if p.HandID == h.ID && !p.Tool && p.Type == t {
+			return p
+		}
+	}
+	return nil
+}
+
+// FingertipPositions returns the tip positions of the fingers of the hand
+// tracked in frame, ordered by finger type from the thumb to the pinky. Tools
+// aren't included.
+func (h *Hand) FingertipPositions(frame *Frame) [][]float64 {
+	var fingers []*Pointable
+	for i := range frame.Pointables {
+		p := &frame.Pointables[i]
+		if p.HandID == h.ID && !p.Tool && isVector(p.TipPosition) {
+			fingers = append(fingers, p)
+		}
+	}
+	sort.SliceStable(fingers, func(i, j int) bool {
+		return fingers[i].Type < fingers[j].Type
+	})
+
+	positions := make([][]float64, len(fingers))
+	for i, p := range fingers {
+		positions[i] = copyVector(p.TipPosition)
+	}
+	return positions
+}
+
+// FingertipCentroid returns the average tip position of the extended fingers
+// of the hand tracked in frame, a stable single pointer when several fingers
+// are extended. Tools aren't included. The bool is false if no finger of the
+// hand is extended.
+func (h *Hand) FingertipCentroid(frame *Frame) ([]float64, bool) {
+	centroid := []float64{0, 0, 0}
+	n := 0
+	for _, p := range frame.Pointables {
+		if p.HandID != h.ID || p.Tool || !p.Extended || !isVector(p.TipPosition) {
+			continue
+		}
+		centroid[0] += p.TipPosition[0]
+		centroid[1] += p.TipPosition[1]
+		centroid[2] += p.TipPosition[2]
+		n++
+	}
+	if n == 0 {
+		return nil, false
+	}
+
+	centroid[0] /= float64(n)
+	centroid[1] /= float64(n)
+	centroid[2] /= float64(n)
+	return centroid, true
+}
+
+// PinchDistance returns the distance in millimeters between the tips of the
+// thumb and index finger of the hand. The bool is false if either finger isn't
+// tracked in frame.
+func (h *Hand) PinchDistance(frame *Frame) (float64, bool) {
+	return h.tipDistance(frame, FingerThumb, FingerIndex)
+}
+
+// Span returns the distance in millimeters between the tips of the thumb and
+// pinky of the hand, the hand span when the hand is open. The bool is false if
+// either finger isn't tracked in frame.
+func (h *Hand) Span(frame *Frame) (float64, bool) {
+	return h.tipDistance(frame, FingerThumb, FingerPinky)
+}
+
+// FingersTouching reports whether the tips of fingers a and b of the hand are
+// at most maxDist millimeters apart, e.g. the thumb and index finger of an OK
+// sign. It's false if either finger isn't tracked in frame.
+func (h *Hand) FingersTouching(frame *Frame, a, b FingerType, maxDist float64) bool {
+	d, ok := h.tipDistance(frame, a, b)
+	return ok && d <= maxDist
+}
+
+// tipDistance returns the distance between the tips of two fingers of the hand
+func (h *Hand) tipDistance(frame *Frame, a, b FingerType) (float64, bool) {
+	fa := h.Finger(frame, a)
+	fb := h.Finger(frame, b)
+	if fa == nil || fb == nil || !isVector(fa.TipPosition) || !isVector(fb.TipPosition) {
+		return 0, false
+	}
+
+	return distance(fa.TipPosition, fb.TipPosition), true
+}
@@ -0,0 +1,188 @@
+package leapmotion
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestPinchDistance(t *testing.T) {
+	frame := &Frame{
+		Hands: []Hand{{ID: 1}, {ID: 2}},
+		Pointables: []Pointable{
+			{HandID: 1, Type: FingerThumb, TipPosition: []float64{0, 200, 0}},
+			{HandID: 1, Type: FingerIndex, TipPosition: []float64{30, 240, 0}},
+			{HandID: 2, Type: FingerIndex, TipPosition: []float64{0, 0, 0}},
+		},
+	}
+
+	d, ok := frame.Hands[0].PinchDistance(frame)
+	if !ok || d != 50 {
+		t.Fatalf("Received %f, %t. Expected 50, true", d, ok)
+	}
+
+	if _, ok := frame.Hands[1].PinchDistance(frame); ok {
+		t.Fatal("Expected no pinch distance for a hand without a tracked thumb")
+	}
+}
+
+func TestFingertipPositions(t *testing.T) {
+	frame := &Frame{
+		Hands: []Hand{{ID: 1}},
+		Pointables: []Pointable{
+			{HandID: 1, Type: FingerPinky, TipPosition: []float64{4, 0, 0}},
+			{HandID: 1, Type: FingerThumb, TipPosition: []float64{0, 0, 0}},
+			{HandID: 1, Type: FingerIndex, TipPosition: []float64{1, 0, 0}},
+			{HandID: 1, Tool: true, TipPosition: []float64{9, 0, 0}},
+			{HandID: 2, Type: FingerMiddle, TipPosition: []float64{8, 0, 0}},
+		},
+	}
+
+	positions := frame.Hands[0].FingertipPositions(frame)
+	expected := []float64{0, 1, 4}
+	if len(positions) != len(expected) {
+		t.Fatalf("Received %f. Expected the thumb, index and pinky tips", positions)
+	}
+	for i, p := range positions {
+		if p[0] != expected[i] {
+			t.Fatalf("Received %f. Expected tips ordered from the thumb", positions)
+		}
+	}
+}
+
+func TestSpan(t *testing.T) {
+	frame := &Frame{
+		Hands: []Hand{{ID: 1}, {ID: 2}},
+		Pointables: []Pointable{
+			{HandID: 1, Type: FingerThumb, TipPosition: []float64{-90, 200, 0}},
+			{HandID: 1, Type: FingerPinky, TipPosition: []float64{90, 200, 0}},
+			{HandID: 2, Type: FingerThumb, TipPosition: []float64{0, 0, 0}},
+		},
+	}
+
+	d, ok := frame.Hands[0].Span(frame)
+	if !ok || d != 180 {
+		t.Fatalf("Received %f, %t. Expected 180, true", d, ok)
+	}
+
+	if _, ok := frame.Hands[1].Span(frame); ok {
+		t.Fatal("Expected no span for a hand without a tracked pinky")
+	}
+}
+
+func TestHandUnmarshalJSON(t *testing.T) {
+	// encoding/json matches keys case-insensitively, so the capitalized keys
+	// of some older and custom servers decode too
+	tests := []string{
+		`{"id": 1, "palmPosition": [1, 2, 3], "palmVelocity": [4, 5, 6]}`,
+		`{"id": 1, "PalmPosition": [1, 2, 3], "PalmVelocity": [4, 5, 6]}`,
+	}
+
+	for _, test := range tests {
+		var hand Hand
+		if err := json.Unmarshal([]byte(test), &hand); err != nil {
+			t.Fatal(err)
+		}
+		if hand.ID != 1 || hand.PalmPositionV() != (Vector{1, 2, 3}) || hand.PalmVelocityV() != (Vector{4, 5, 6}) {
+			t.Fatalf("Received %+v. Expected the palm of %s", hand, test)
+		}
+	}
+}
+
+func TestFingertipCentroid(t *testing.T) {
+	frame := &Frame{
+		Hands: []Hand{{ID: 1}, {ID: 2}},
+		Pointables: []Pointable{
+			{HandID: 1, Extended: true, TipPosition: []float64{0, 200, 0}},
+			{HandID: 1, Extended: true, TipPosition: []float64{30, 220, -30}},
+			{HandID: 1, Extended: false, TipPosition: []float64{90, 90, 90}},
+			{HandID: 1, Extended: true, Tool: true, TipPosition: []float64{90, 90, 90}},
+			{HandID: 2, Extended: false, TipPosition: []float64{0, 0, 0}},
+		},
+	}
+
+	centroid, ok := frame.Hands[0].FingertipCentroid(frame)
+	if !ok || centroid[0] != 15 || centroid[1] != 210 || centroid[2] != -15 {
+		t.Fatalf("Received %v, %t. Expected [15 210 -15], true", centroid, ok)
+	}
+
+	if _, ok := frame.Hands[1].FingertipCentroid(frame); ok {
+		t.Fatal("Expected no centroid for a hand without extended fingers")
+	}
+}
+
+func TestFacingSensor(t *testing.T) {
+	tests := []struct {
+		hand     Hand
+		expected bool
+	}{
+		{Hand{PalmNormal: []float64{0, 0, -1}}, true},
+		{Hand{PalmNormal: []float64{0, -1, 0}, Direction: []float64{0, 0.2, -1}}, true},
+		{Hand{PalmNormal: []float64{0, -1, -1}}, false}, // 45 degrees off
+		{Hand{PalmNormal: []float64{0, 0, 1}, Direction: []float64{0, 1, 0}}, false},
+		{Hand{}, false},
+	}
+
+	for i, test := range tests {
+		if facing := test.hand.FacingSensor(30); facing != test.expected {
+			t.Fatalf("Test %d: received %t. Expected %t", i, facing, test.expected)
+		}
+	}
+}
+
+func TestFingersTouching(t *testing.T) {
+	frame := &Frame{
+		Hands: []Hand{{ID: 1}},
+		Pointables: []Pointable{
+			{HandID: 1, Type: FingerThumb, TipPosition: []float64{0, 200, 0}},
+			{HandID: 1, Type: FingerIndex, TipPosition: []float64{6, 208, 0}},
+			{HandID: 1, Type: FingerMiddle, TipPosition: []float64{40, 250, 0}},
+		},
+	}
+	hand := &frame.Hands[0]
+
+	if !hand.FingersTouching(frame, FingerThumb, FingerIndex, 15) {
+		t.Fatal("Expected the thumb and index finger to touch")
+	}
+	if hand.FingersTouching(frame, FingerThumb, FingerMiddle, 15) {
+		t.Fatal("Expected the thumb and middle finger not to touch")
+	}
+	if hand.FingersTouching(frame, FingerThumb, FingerPinky, 1000) {
+		t.Fatal("Expected an untracked finger not to touch")
+	}
+}
+
+func TestFingers(t *testing.T) {
+	frame := &Frame{
+		Hands: []Hand{{ID: 1}, {ID: 2}, {ID: 3}},
+		Pointables: []Pointable{
+			{ID: 10, HandID: 1, Type: FingerThumb},
+			{ID: 20, HandID: 2, Type: FingerIndex},
+			{ID: 11, HandID: 1, Type: FingerIndex},
+			{ID: 12, HandID: 1, Tool: true},
+		},
+	}
+
+	tests := []struct {
+		hand     *Hand
+		expected []int
+	}{
+		{&frame.Hands[0], []int{10, 11}},
+		{&frame.Hands[1], []int{20}},
+		{&frame.Hands[2], []int{}},
+	}
+
+	for _, test := range tests {
+		fingers := test.hand.Fingers(frame)
+		if fingers == nil {
+			t.Fatalf("Received nil for hand %d. Expected an empty slice", test.hand.ID)
+		}
+		if len(fingers) != len(test.expected) {
+			t.Fatalf("Received %d fingers for hand %d. Expected %v", len(fingers), test.hand.ID, test.expected)
+		}
+		for i, p := range fingers {
+			if p.ID != test.expected[i] {
+				t.Fatalf("Received finger %d for hand %d. Expected %v", p.ID, test.hand.ID, test.expected)
+			}
+		}
+	}
+}
@@ -0,0 +1,153 @@
+package leapmotion
+
+import "time"
+
+// healthWindow is the time span Health computes its rates over
+const healthWindow = 5 * time.Second
+
+// HealthReport summarizes the health of the frame stream over the last
+// Window, for diagnostics overlays
+type HealthReport struct {
+	// Window is the time span the rates are computed over: five seconds, or
+	// less while the client has been receiving for less than that
+	Window time.Duration
+	// DeliveredFPS is the rate of frames handed to the frameHandler, after
+	// Pause, the middleware and the dropping options
+	DeliveredFPS float64
+	// ReportedFPS is the mean frame rate the Leap Motion service reported in
+	// the frames received
+	ReportedFPS float64
+	// DroppedRatio is the fraction of frames missing from the sequence of
+	// frame IDs received, e.g. lost behind a slow relay
+	DroppedRatio float64
+	// DecodeErrorRate is the fraction of the messages received that failed
+	// to decode
+	DecodeErrorRate float64
+	// SinceLastFrame is how long ago the latest frame was received. It's 0
+	// until a frame has been received.
+	SinceLastFrame time.Duration
+}
+
+// healthSamples is the history of the stream Health computes its report
+// from. It's guarded by Client.mu.
+type healthSamples struct {
+	since     time.Time // the first sample
+	received  []healthSample
+	delivered []time.Time
+}
+
+type healthSample struct {
+	at     time.Time
+	failed bool // the message failed to decode
+	id     float64
+	fps    float64
+}
+
+func (h *healthSamples) add(s healthSample) {
+	if h.since.IsZero() {
+		h.since = s.at
+	}
+	h.received = append(trimSamples(h.received, s.at), s)
+}
+
+func (h *healthSamples) deliver(at time.Time) {
+	if h.since.IsZero() {
+		h.since = at
+	}
+	h.delivered = append(trimTimes(h.delivered, at), at)
+}
+
+// trimSamples drops the samples older than healthWindow before now
+func trimSamples(samples []healthSample, now time.Time) []healthSample {
+	i := 0
+	for i < len(samples) && now.Sub(samples[i].at) > healthWindow {
+		i++
+	}
+	if i == 0 {
+		return samples
+	}
+	return append(samples[:0], samples[i:]...)
+}
+
+func trimTimes(times []time.Time, now time.Time) []time.Time {
+	i := 0
+	for i < len(times) && now.Sub(times[i]) > healthWindow {
+		i++
+	}
+	if i == 0 {
+		return times
+	}
+	return append(times[:0], times[i:]...)
+}
+
+// recordDecodeError counts a message that failed to decode in Health
+func (c *Client) recordDecodeError(at time.Time) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.health.add(healthSample{at: at, failed: true})
+}
+
+// Health returns a report of the stream health over the last five seconds
+func (c *Client) Health() HealthReport {
+	now := time.Now()
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	var report HealthReport
+	if !c.lastReceivedAt.IsZero() {
+		report.SinceLastFrame = now.Sub(c.lastReceivedAt)
+	}
+	if c.health.since.IsZero() {
+		return report
+	}
+
+	c.health.received = trimSamples(c.health.received, now)
+	c.health.delivered = trimTimes(c.health.delivered, now)
+
+	report.Window = healthWindow
+	if age := now.Sub(c.health.since); age < healthWindow {
+		report.Window = age
+	}
+	if report.Window > 0 {
+		report.DeliveredFPS = float64(len(c.health.delivered)) / report.Window.Seconds()
+	}
+
+	var frames, failed int
+	var fps, expected, dropped float64
+	var prev *healthSample
+	for i := range c.health.received {
+		s := &c.health.received[i]
+		if now.Sub(s.at) > healthWindow {
+			continue
+		}
+		if s.failed {
+			failed++
+			continue
+		}
+		frames++
+		fps += s.fps
+
+		// IDs going backwards, e.g. after a reconnect, or repeating don't
+		// tell anything about drops
+		if prev != nil && s.id > prev.id {
+			gap := s.id - prev.id
+			expected += gap
+			dropped += gap - 1
+		}
+		prev = s
+	}
+
+	if frames > 0 {
+		report.ReportedFPS = fps / float64(frames)
+	}
+	if expected > 0 {
+		report.DroppedRatio = dropped / expected
+	}
+	if n := frames + failed; n > 0 {
+		report.DecodeErrorRate = float64(failed) / float64(n)
+	}
+
+	return report
+}
@@ -0,0 +1,50 @@
+package leapmotion
+
+import (
+	"fmt"
+	"math"
+	"testing"
+	"time"
+)
+
+func TestHealth(t *testing.T) {
+	c := newClient(nil, nil)
+
+	if report := c.Health(); report != (HealthReport{}) {
+		t.Fatalf("Received %+v. Expected an empty report before any message", report)
+	}
+
+	// Frames 1 to 10 with 3 and 7 missing, and 2 messages failing to decode
+	at := time.Now().Add(-time.Second)
+	for id := 1; id <= 10; id++ {
+		if id == 3 || id == 7 {
+			continue
+		}
+		c.handleMessageAt([]byte(fmt.Sprintf(`{"id": %d, "currentFrameRate": %d}`, id, 100+id%2*10)), at)
+	}
+	c.handleMessageAt([]byte(`{"id": 11,, }`), at)
+	c.handleMessageAt([]byte(`not json`), at)
+
+	// A message older than the window doesn't count
+	c.handleMessageAt([]byte(`oops`), time.Now().Add(-time.Minute))
+
+	report := c.Health()
+	if report.Window <= 0 || report.Window > healthWindow {
+		t.Fatalf("Received window %v. Expected up to %v", report.Window, healthWindow)
+	}
+	if math.Abs(report.DeliveredFPS-8/report.Window.Seconds()) > 1e-6 {
+		t.Fatalf("Received %f delivered fps. Expected 8 frames over %v", report.DeliveredFPS, report.Window)
+	}
+	if report.ReportedFPS != 103.75 {
+		t.Fatalf("Received %f reported fps. Expected 103.75", report.ReportedFPS)
+	}
+	if math.Abs(report.DroppedRatio-2.0/9) > 1e-9 {
+		t.Fatalf("Received dropped ratio %f. Expected %f", report.DroppedRatio, 2.0/9)
+	}
+	if report.DecodeErrorRate != 0.2 {
+		t.Fatalf("Received decode error rate %f. Expected 0.2", report.DecodeErrorRate)
+	}
+	if report.SinceLastFrame < time.Second {
+		t.Fatalf("Received %v since the last frame. Expected at least 1s", report.SinceLastFrame)
+	}
+}
@@ -0,0 +1,38 @@
+package leapmotion
+
+// frameRing holds the latest frames received, up to size
+type frameRing struct {
+	size   int
+	frames []*Frame
+	next   int // index of the oldest frame once frames is full
+}
+
+func (r *frameRing) push(frame *Frame) {
+	if len(r.frames) < r.size {
+		r.frames = append(r.frames, frame)
+		return
+	}
+
+	r.frames[r.next] = frame
+	r.next = (r.next + 1) % r.size
+}
+
+// all returns deep copies of the frames, oldest first
+func (r *frameRing) all() []*Frame {
+	frames := make([]*Frame, len(r.frames))
+	for i := range frames {
+		frames[i] = r.frames[(r.next+i)%len(r.frames)].Clone()
+	}
+	return frames
+}
+
+// History returns the frames retained with WithFrameHistory, oldest first and
+// the latest frame last, e.g. the last three to compute the acceleration of a
+// palm. The frames are deep copies that can be kept and modified. It's empty
+// without WithFrameHistory and safe to call while frames are being received.
+func (c *Client) History() []*Frame {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.history.all()
+}
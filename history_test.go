@@ -0,0 +1,61 @@
+package leapmotion
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestHistory(t *testing.T) {
+	c := newClient(nil, []Option{WithFrameHistory(5)})
+
+	if frames := c.History(); len(frames) != 0 {
+		t.Fatalf("Received %d frames. Expected none before any frame", len(frames))
+	}
+
+	for id := 1; id <= 15; id++ {
+		c.handleMessage([]byte(fmt.Sprintf(`{"id": %d, "hands": [{"id": 1, "palmPosition": [0, %d, 0]}]}`, id, id)))
+	}
+
+	frames := c.History()
+	if len(frames) != 5 {
+		t.Fatalf("Received %d frames. Expected the 5 retained", len(frames))
+	}
+	for i, frame := range frames {
+		if expected := float64(11 + i); frame.ID != expected {
+			t.Fatalf("Received frame %v at %d. Expected frame %v, newest last", frame.ID, i, expected)
+		}
+	}
+
+	// The frames are copies
+	frames[4].Hands[0].ID = 9
+	if frame := c.History()[4]; frame.Hands[0].ID != 1 {
+		t.Fatal("Expected modifying a returned frame to leave the history unchanged")
+	}
+}
+
+func TestWithFrameHistory(t *testing.T) {
+	tests := []struct {
+		opts     []Option
+		expected int
+	}{
+		{nil, 0},
+		{[]Option{WithFrameHistory(0)}, 0},
+		{[]Option{WithFrameHistory(5)}, 5},
+		{[]Option{WithFrameHistory(200)}, 100},
+	}
+
+	for _, test := range tests {
+		c := newClient(nil, test.opts)
+		for id := 1; id <= 100; id++ {
+			c.handleMessage([]byte(fmt.Sprintf(`{"id": %d}`, id)))
+		}
+
+		frames := c.History()
+		if len(frames) != test.expected {
+			t.Fatalf("Received %d frames. Expected %d", len(frames), test.expected)
+		}
+		if len(frames) > 0 && frames[len(frames)-1].ID != 100 {
+			t.Fatalf("Received frame %v last. Expected the latest frame 100", frames[len(frames)-1].ID)
+		}
+	}
+}
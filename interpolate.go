@@ -0,0 +1,237 @@
+package leapmotion
+
+import "math"
+
+// Interpolate returns the frame at fraction t between a (t = 0) and b (t = 1),
+// e.g. to render at display rate from a slower stream. Palm and finger
+// positions, velocities and scalars are interpolated linearly, palm normals,
+// directions and rotation matrices spherically. Hands and pointables only in
+// one of the frames are passed through unchanged. Other fields, such as the
+// gestures, are taken from the nearest frame.
+func Interpolate(a, b *Frame, t float64) *Frame {
+	if a == nil {
+		return b.Clone()
+	}
+	if b == nil {
+		return a.Clone()
+	}
+
+	near := a
+	if t >= 0.5 {
+		near = b
+	}
+	f := near.Clone()
+
+	f.Timestamp = a.Timestamp + int(math.Round(float64(b.Timestamp-a.Timestamp)*t))
+	f.CurrentFrameRate = lerp(a.CurrentFrameRate, b.CurrentFrameRate, t)
+	f.R = slerpMatrix(a.R, b.R, t, near.R)
+	f.T = lerpVector(a.T, b.T, t, near.T)
+
+	bHands := make(map[int]*Hand, len(b.Hands))
+	for i := range b.Hands {
+		bHands[b.Hands[i].ID] = &b.Hands[i]
+	}
+	f.Hands = nil
+	for i := range a.Hands {
+		ha := &a.Hands[i]
+		if hb, ok := bHands[ha.ID]; ok {
+			f.Hands = append(f.Hands, interpolateHand(ha, hb, t))
+			delete(bHands, ha.ID)
+		} else {
+			f.Hands = append(f.Hands, ha.clone())
+		}
+	}
+	for i := range b.Hands {
+		if _, ok := bHands[b.Hands[i].ID]; ok {
+			f.Hands = append(f.Hands, b.Hands[i].clone())
+		}
+	}
+
+	bPointables := make(map[int]*Pointable, len(b.Pointables))
+	for i := range b.Pointables {
+		bPointables[b.Pointables[i].ID] = &b.Pointables[i]
+	}
+	f.Pointables = nil
+	for i := range a.Pointables {
+		pa := &a.Pointables[i]
+		if pb, ok := bPointables[pa.ID]; ok {
+			f.Pointables = append(f.Pointables, interpolatePointable(pa, pb, t))
+			delete(bPointables, pa.ID)
+		} else {
+			f.Pointables = append(f.Pointables, pa.clone())
+		}
+	}
+	for i := range b.Pointables {
+		if _, ok := bPointables[b.Pointables[i].ID]; ok {
+			f.Pointables = append(f.Pointables, b.Pointables[i].clone())
+		}
+	}
+
+	return f
+}
+
+func interpolateHand(a, b *Hand, t float64) Hand {
+	near := a
+	if t >= 0.5 {
+		near = b
+	}
+	h := near.clone()
+
+	h.Confidence = lerp(a.Confidence, b.Confidence, t)
+	h.GrabStrength = lerp(a.GrabStrength, b.GrabStrength, t)
+	h.PinchStrength = lerp(a.PinchStrength, b.PinchStrength, t)
+	h.SphereRadius = lerp(a.SphereRadius, b.SphereRadius, t)
+	h.TimeVisible = lerp(a.TimeVisible, b.TimeVisible, t)
+
+	h.PalmPosition = lerpVector(a.PalmPosition, b.PalmPosition, t, near.PalmPosition)
+	h.StabilizedPalmPosition = lerpVector(a.StabilizedPalmPosition, b.StabilizedPalmPosition, t, near.StabilizedPalmPosition)
+	h.PalmVelocity = lerpVector(a.PalmVelocity, b.PalmVelocity, t, near.PalmVelocity)
+	h.SphereCenter = lerpVector(a.SphereCenter, b.SphereCenter, t, near.SphereCenter)
+	h.Elbow = lerpVector(a.Elbow, b.Elbow, t, near.Elbow)
+	h.Wrist = lerpVector(a.Wrist, b.Wrist, t, near.Wrist)
+	h.T = lerpVector(a.T, b.T, t, near.T)
+
+	h.PalmNormal = slerpVector(a.PalmNormal, b.PalmNormal, t, near.PalmNormal)
+	h.Direction = slerpVector(a.Direction, b.Direction, t, near.Direction)
+	h.R = slerpMatrix(a.R, b.R, t, near.R)
+
+	return h
+}
+
+func interpolatePointable(a, b *Pointable, t float64) Pointable {
+	near := a
+	if t >= 0.5 {
+		near = b
+	}
+	p := near.clone()
+
+	p.Length = lerp(a.Length, b.Length, t)
+	p.TimeVisible = lerp(a.TimeVisible, b.TimeVisible, t)
+	p.TouchDistance = lerp(a.TouchDistance, b.TouchDistance, t)
+	p.Width = lerp(a.Width, b.Width, t)
+
+	p.TipPosition = lerpVector(a.TipPosition, b.TipPosition, t, near.TipPosition)
+	p.StabilizedTipPosition = lerpVector(a.StabilizedTipPosition, b.StabilizedTipPosition, t, near.StabilizedTipPosition)
+	p.TipVelocity = lerpVector(a.TipVelocity, b.TipVelocity, t, near.TipVelocity)
+	p.BtipPosition = lerpVector(a.BtipPosition, b.BtipPosition, t, near.BtipPosition)
+	p.CarpPosition = lerpVector(a.CarpPosition, b.CarpPosition, t, near.CarpPosition)
+	p.DipPosition = lerpVector(a.DipPosition, b.DipPosition, t, near.DipPosition)
+	p.McpPosition = lerpVector(a.McpPosition, b.McpPosition, t, near.McpPosition)
+	p.PipPosition = lerpVector(a.PipPosition, b.PipPosition, t, near.PipPosition)
+
+	p.Direction = slerpVector(a.Direction, b.Direction, t, near.Direction)
+
+	return p
+}
+
+func lerp(a, b, t float64) float64 {
+	return a + (b-a)*t
+}
+
+// lerpVector interpolates a and b linearly, or copies fallback if either
+// isn't a vector
+func lerpVector(a, b []float64, t float64, fallback []float64) []float64 {
+	if !isVector(a) || !isVector(b) {
+		return cloneFloats(fallback)
+	}
+	return []float64{lerp(a[0], b[0], t), lerp(a[1], b[1], t), lerp(a[2], b[2], t)}
+}
+
+// slerpVector interpolates the directions a and b along the great circle
+// between them, or copies fallback if either isn't a direction
+func slerpVector(a, b []float64, t float64, fallback []float64) []float64 {
+	if !isVector(a) || !isVector(b) {
+		return cloneFloats(fallback)
+	}
+	na, nb := normalize(a), normalize(b)
+	if na == nil || nb == nil {
+		return cloneFloats(fallback)
+	}
+
+	dot := math.Max(-1, math.Min(1, na[0]*nb[0]+na[1]*nb[1]+na[2]*nb[2]))
+	theta := math.Acos(dot)
+	if math.Sin(theta) < 1e-6 {
+		// Nearly parallel (or opposite, where any great circle will do)
+		if v := normalize(lerpVector(na, nb, t, nil)); v != nil {
+			return v
+		}
+		return cloneFloats(fallback)
+	}
+
+	wa := math.Sin((1-t)*theta) / math.Sin(theta)
+	wb := math.Sin(t*theta) / math.Sin(theta)
+	return []float64{wa*na[0] + wb*nb[0], wa*na[1] + wb*nb[1], wa*na[2] + wb*nb[2]}
+}
+
+// slerpMatrix interpolates the rotation matrices a and b through their
+// quaternions, or copies fallback if either isn't a 3x3 matrix
+func slerpMatrix(a, b [][]float64, t float64, fallback [][]float64) [][]float64 {
+	if !isMatrix(a) || !isMatrix(b) {
+		return cloneMatrix(fallback)
+	}
+
+	qa, qb := quaternion(a), quaternion(b)
+
+	dot := qa[0]*qb[0] + qa[1]*qb[1] + qa[2]*qb[2] + qa[3]*qb[3]
+	if dot < 0 {
+		// Take the short way around
+		for k := range qb {
+			qb[k] = -qb[k]
+		}
+		dot = -dot
+	}
+
+	var q [4]float64
+	if dot > 1-1e-6 {
+		for k := range q {
+			q[k] = lerp(qa[k], qb[k], t)
+		}
+	} else {
+		theta := math.Acos(dot)
+		wa := math.Sin((1-t)*theta) / math.Sin(theta)
+		wb := math.Sin(t*theta) / math.Sin(theta)
+		for k := range q {
+			q[k] = wa*qa[k] + wb*qb[k]
+		}
+	}
+
+	return rotationMatrix(q)
+}
+
+func isMatrix(m [][]float64) bool {
+	return len(m) >= 3 && isVector(m[0]) && isVector(m[1]) && isVector(m[2])
+}
+
+// quaternion returns the unit quaternion (w, x, y, z) of a rotation matrix
+func quaternion(m [][]float64) [4]float64 {
+	var q [4]float64
+	trace := m[0][0] + m[1][1] + m[2][2]
+	switch {
+	case trace > 0:
+		s := math.Sqrt(trace+1) * 2
+		q = [4]float64{s / 4, (m[2][1] - m[1][2]) / s, (m[0][2] - m[2][0]) / s, (m[1][0] - m[0][1]) / s}
+	case m[0][0] > m[1][1] && m[0][0] > m[2][2]:
+		s := math.Sqrt(1+m[0][0]-m[1][1]-m[2][2]) * 2
+		q = [4]float64{(m[2][1] - m[1][2]) / s, s / 4, (m[0][1] + m[1][0]) / s, (m[0][2] + m[2][0]) / s}
+	case m[1][1] > m[2][2]:
+		s := math.Sqrt(1+m[1][1]-m[0][0]-m[2][2]) * 2
+		q = [4]float64{(m[0][2] - m[2][0]) / s, (m[0][1] + m[1][0]) / s, s / 4, (m[1][2] + m[2][1]) / s}
+	default:
+		s := math.Sqrt(1+m[2][2]-m[0][0]-m[1][1]) * 2
+		q = [4]float64{(m[1][0] - m[0][1]) / s, (m[0][2] + m[2][0]) / s, (m[1][2] + m[2][1]) / s, s / 4}
+	}
+	return q
+}
+
+// rotationMatrix returns the rotation matrix of the quaternion (w, x, y, z),
+// normalizing it first
+func rotationMatrix(q [4]float64) [][]float64 {
+	n := math.Sqrt(q[0]*q[0] + q[1]*q[1] + q[2]*q[2] + q[3]*q[3])
+	w, x, y, z := q[0]/n, q[1]/n, q[2]/n, q[3]/n
+
+	return [][]float64{
+		{1 - 2*(y*y+z*z), 2 * (x*y - w*z), 2 * (x*z + w*y)},
+		{2 * (x*y + w*z), 1 - 2*(x*x+z*z), 2 * (y*z - w*x)},
+		{2 * (x*z - w*y), 2 * (y*z + w*x), 1 - 2*(x*x+y*y)},
+	}
+}
@@ -0,0 +1,73 @@
+package leapmotion
+
+import (
+	"math"
+	"testing"
+)
+
+func TestInterpolate(t *testing.T) {
+	identity := [][]float64{{1, 0, 0}, {0, 1, 0}, {0, 0, 1}}
+	// 90 degrees about Z
+	quarter := [][]float64{{0, -1, 0}, {1, 0, 0}, {0, 0, 1}}
+
+	a := &Frame{
+		ID:        1,
+		Timestamp: 1000,
+		Hands: []Hand{
+			{ID: 1, PalmPosition: []float64{0, 100, 0}, PalmNormal: []float64{0, -1, 0}, R: identity},
+			{ID: 2, PalmPosition: []float64{50, 50, 50}},
+		},
+		Pointables: []Pointable{{ID: 10, HandID: 1, TipPosition: []float64{0, 0, 0}}},
+	}
+	b := &Frame{
+		ID:        2,
+		Timestamp: 2000,
+		Hands: []Hand{
+			{ID: 1, PalmPosition: []float64{100, 200, 0}, PalmNormal: []float64{1, 0, 0}, R: quarter},
+		},
+		Pointables: []Pointable{{ID: 10, HandID: 1, TipPosition: []float64{10, 20, 30}}},
+	}
+
+	f := Interpolate(a, b, 0.25)
+
+	if f.Timestamp != 1250 || f.ID != 1 {
+		t.Fatalf("Received timestamp %d and id %v. Expected 1250 and 1", f.Timestamp, f.ID)
+	}
+	if len(f.Hands) != 2 {
+		t.Fatalf("Received %d hands. Expected the hand only in a to pass through", len(f.Hands))
+	}
+
+	h := f.Hands[0]
+	if p := h.PalmPosition; p[0] != 25 || p[1] != 125 || p[2] != 0 {
+		t.Fatalf("Received palm position %f. Expected [25 125 0]", p)
+	}
+
+	// A quarter of the way from -Y to +X is 22.5 degrees from -Y
+	angle := math.Pi / 8
+	if n := h.PalmNormal; math.Abs(n[0]-math.Sin(angle)) > 1e-9 || math.Abs(n[1]+math.Cos(angle)) > 1e-9 {
+		t.Fatalf("Received palm normal %f", n)
+	}
+	if r := h.R; math.Abs(r[0][0]-math.Cos(angle)) > 1e-9 || math.Abs(r[1][0]-math.Sin(angle)) > 1e-9 {
+		t.Fatalf("Received rotation %f. Expected 22.5 degrees about Z", r)
+	}
+
+	if p := f.Pointables[0].TipPosition; p[0] != 2.5 || p[1] != 5 || p[2] != 7.5 {
+		t.Fatalf("Received tip position %f. Expected [2.5 5 7.5]", p)
+	}
+
+	// The inputs are left untouched
+	if a.Hands[0].PalmPosition[0] != 0 || b.Hands[0].PalmPosition[0] != 100 {
+		t.Fatal("Expected the interpolated frames not to be modified")
+	}
+}
+
+func TestFrameClone(t *testing.T) {
+	f := &Frame{Hands: []Hand{{PalmPosition: []float64{1, 2, 3}}}}
+
+	c := f.Clone()
+	c.Hands[0].PalmPosition[0] = 10
+
+	if f.Hands[0].PalmPosition[0] != 1 {
+		t.Fatal("Expected the clone not to share slices with the frame")
+	}
+}
@@ -0,0 +1,37 @@
+//go:build go1.23
+
+package leapmotion
+
+import (
+	"context"
+	"iter"
+)
+
+// All returns an iterator over the frames the client delivers from now on,
+// after the middleware, so they can be consumed with
+//
+//	for frame := range c.All(ctx) {
+//	}
+//
+// The iteration ends when ctx is cancelled or the client is closed. Frames
+// are dropped if the loop body falls behind the stream. The frameHandler is
+// still called for every frame.
+func (c *Client) All(ctx context.Context) iter.Seq[*Frame] {
+	return func(yield func(*Frame) bool) {
+		frames, unsubscribe := c.subscribe()
+		defer unsubscribe()
+
+		for {
+			select {
+			case frame := <-frames:
+				if !yield(frame) {
+					return
+				}
+			case <-ctx.Done():
+				return
+			case <-c.done:
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,41 @@
+//go:build go1.23
+
+package leapmotion
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestAll(t *testing.T) {
+	transport := newFakeTransport()
+
+	c, err := Connect(nil, WithDialer(transport.dialer()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer c.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	go func() {
+		// Give the loop time to subscribe
+		time.Sleep(20 * time.Millisecond)
+		transport.messages <- []byte(`{"id": 1}`)
+		transport.messages <- []byte(`{"id": 2}`)
+	}()
+
+	var ids []float64
+	for frame := range c.All(ctx) {
+		ids = append(ids, frame.ID)
+		if len(ids) == 2 {
+			break
+		}
+	}
+
+	if len(ids) != 2 || ids[0] != 1 || ids[1] != 2 {
+		t.Fatalf("Received frames %v. Expected [1 2]", ids)
+	}
+}
@@ -3,19 +3,24 @@
 package leapmotion
 
 import (
-	"errors"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
 	"math"
-
-	"golang.org/x/net/websocket"
+	"net/url"
+	"sync"
+	"time"
 )
 
 const (
 	defaultLeapWebSocketAddress = "ws://localhost:6437/v6.json"
+	errorsBuffer                = 16
+	messagesBuffer              = 8
 )
 
 // DeviceEvent is sent from the server to the client when the Leap Motion when the service/daemon
-// is paused or resumed and when the controller hardware is plugged in or unplugged:
-// TODO: this is not implemented
+// is paused or resumed and when the controller hardware is plugged in or unplugged
 type DeviceEvent struct {
 	ID        string `json:"id"`
 	Attached  bool   `json:"attached"`
@@ -25,6 +30,8 @@ type DeviceEvent struct {
 
 // Frame represents the tracking data format
 // https://developer.leapmotion.com/documentation/javascript/supplements/Leap_JSON.html#json-tracking-data-format
+// Positions and lengths are in millimeters, velocities in millimeters per second
+// and Timestamp in microseconds.
 type Frame struct {
 	CurrentFrameRate float64        `json:"currentFrameRate"`
 	ID               float64        `json:"id"`
@@ -36,6 +43,13 @@ type Frame struct {
 	Hands            []Hand         `json:"hands"`
 	InteractionBox   InteractionBox `json:"interactionBox"`
 	Pointables       []Pointable    `json:"pointables"`
+
+	// Synthetic is true for the empty frames WithIdleFrames generates when
+	// the stream goes quiet
+	Synthetic bool `json:"-"`
+	// ReceivedAt is the host wall clock time the frame was read from the
+	// socket, unlike Timestamp which is in the Leap Motion clock domain
+	ReceivedAt time.Time `json:"-"`
 }
 
 // Gesture represents a Gesture object in a Frame
@@ -58,7 +72,7 @@ type Gesture struct {
 
 // Hand represents a Hand object in a Frame
 type Hand struct {
-	ArmBasis               []float64   `json:"armBasis"`
+	ArmBasis               [][]float64 `json:"armBasis"` // 3x3, one row per axis
 	ArmWidth               float64     `json:"armWidth"`
 	Confidence             float64     `json:"confidence"`
 	Direction              []float64   `json:"direction"`
@@ -66,8 +80,8 @@ type Hand struct {
 	GrabStrength           float64     `json:"grabStrength"`
 	ID                     int         `json:"id"`
 	PalmNormal             []float64   `json:"palmNormal"`
-	PalmPosition           []float64   `json:"PalmPosition"`
-	PalmVelocity           []float64   `json:"PalmVelocity"`
+	PalmPosition           []float64   `json:"palmPosition"`
+	PalmVelocity           []float64   `json:"palmVelocity"`
 	PinchStrength          float64     `json:"pinchStrength"`
 	R                      [][]float64 `json:"r"`
 	S                      float64     `json:"s"`
@@ -75,7 +89,7 @@ type Hand struct {
 	SphereRadius           float64     `json:"sphereRadius"`
 	StabilizedPalmPosition []float64   `json:"stabilizedPalmPosition"`
 	T                      []float64   `json:"t"`
-	TimeVisible            float64     `json:"TimeVisible"`
+	TimeVisible            float64     `json:"timeVisible"`
 	Type                   string      `json:"type"`
 	Wrist                  []float64   `json:"wrist"`
 }
@@ -91,21 +105,132 @@ type InteractionBox struct {
 // converted to a range of [0..1] such that the minimum value of the
 // InteractionBox maps to 0 and the maximum value of the InteractionBox maps to 1.
 func (i *InteractionBox) NormalizePoint(position []float64, clamp bool) ([]float64, error) {
+	if err := i.validate(); err != nil {
+		return nil, err
+	}
+	if position == nil || len(position) < 3 {
+		return nil, fmt.Errorf("%w: position isn't set or doesn't have enough values", ErrInvalidVector)
+	}
+
+	return i.normalize(position, clamp), nil
+}
+
+// NormalizePointInto normalizes position like NormalizePoint, writing the
+// result into dst instead of allocating it, for hot loops mapping every
+// fingertip of every frame. dst must have three values; it may be position.
+func (i *InteractionBox) NormalizePointInto(dst, position []float64, clamp bool) error {
+	if err := i.validate(); err != nil {
+		return err
+	}
+	if position == nil || len(position) < 3 {
+		return fmt.Errorf("%w: position isn't set or doesn't have enough values", ErrInvalidVector)
+	}
+	if len(dst) < 3 {
+		return fmt.Errorf("%w: dst doesn't have enough values", ErrInvalidVector)
+	}
+
+	i.normalizeInto(dst, position, clamp)
+	return nil
+}
+
+// NormalizePoints normalizes each of positions like NormalizePoint, checking
+// the interaction box only once
+func (i *InteractionBox) NormalizePoints(positions [][]float64, clamp bool) ([][]float64, error) {
+	if err := i.validate(); err != nil {
+		return nil, err
+	}
+
+	normalized := make([][]float64, len(positions))
+	for k, position := range positions {
+		if position == nil || len(position) < 3 {
+			return nil, fmt.Errorf("%w: position %d isn't set or doesn't have enough values", ErrInvalidVector, k)
+		}
+		normalized[k] = i.normalize(position, clamp)
+	}
+
+	return normalized, nil
+}
+
+// DenormalizePoint is the inverse of NormalizePoint without clamping: it
+// converts normalized coordinates, e.g. of a point on screen, back to
+// millimeters in the Leap Motion frame of reference
+func (i *InteractionBox) DenormalizePoint(normalized []float64) ([]float64, error) {
+	if err := i.validate(); err != nil {
+		return nil, err
+	}
+	if normalized == nil || len(normalized) < 3 {
+		return nil, fmt.Errorf("%w: normalized point isn't set or doesn't have enough values", ErrInvalidVector)
+	}
+
+	return []float64{
+		(normalized[0]-0.5)*i.Size[0] + float64(i.Center[0]),
+		(normalized[1]-0.5)*i.Size[1] + float64(i.Center[1]),
+		(normalized[2]-0.5)*i.Size[2] + float64(i.Center[2]),
+	}, nil
+}
+
+// ToNDC converts the coordinates of a point to normalized device coordinates:
+// the interaction box maps to [-1..1] on every axis. The axes keep their Leap
+// Motion directions, so Y points up as graphics pipelines expect and doesn't
+// need flipping.
+func (i *InteractionBox) ToNDC(position []float64, clamp bool) ([]float64, error) {
+	vec, err := i.NormalizePoint(position, clamp)
+	if err != nil {
+		return nil, err
+	}
+
+	vec[0] = vec[0]*2 - 1
+	vec[1] = vec[1]*2 - 1
+	vec[2] = vec[2]*2 - 1
+
+	return vec, nil
+}
+
+// Width returns the size of the interaction box along the X axis, or 0 if the
+// size isn't set
+func (i *InteractionBox) Width() float64 {
+	return i.size(0)
+}
+
+// Height returns the size of the interaction box along the Y axis, or 0 if the
+// size isn't set
+func (i *InteractionBox) Height() float64 {
+	return i.size(1)
+}
+
+// Depth returns the size of the interaction box along the Z axis, or 0 if the
+// size isn't set
+func (i *InteractionBox) Depth() float64 {
+	return i.size(2)
+}
+
+func (i *InteractionBox) size(axis int) float64 {
+	if len(i.Size) < 3 {
+		return 0
+	}
+	return i.Size[axis]
+}
+
+func (i *InteractionBox) validate() error {
 	if i.Center == nil || len(i.Center) < 3 {
-		return nil, errors.New("Center isn't set or doesn't have enough values")
+		return fmt.Errorf("%w: Center isn't set or doesn't have enough values", ErrInvalidBox)
 	}
 	if i.Size == nil || len(i.Size) < 3 {
-		return nil, errors.New("Size isn't set or doesn't have enough values")
+		return fmt.Errorf("%w: Size isn't set or doesn't have enough values", ErrInvalidBox)
 	}
-	if position == nil || len(position) < 3 {
-		return nil, errors.New("postion isn't set or doesn't have enough values")
+	if i.Size[0] == 0 || i.Size[1] == 0 || i.Size[2] == 0 {
+		return ErrDegenerateBox
 	}
+	return nil
+}
 
+func (i *InteractionBox) normalize(position []float64, clamp bool) []float64 {
 	vec := []float64{0, 0, 0}
-	vec[0] = ((position[0] - float64(i.Center[0])) / i.Size[0]) + 0.5
-	vec[1] = ((position[1] - float64(i.Center[1])) / i.Size[1]) + 0.5
-	vec[2] = ((position[2] - float64(i.Center[2])) / i.Size[2]) + 0.5
+	i.normalizeInto(vec, position, clamp)
+	return vec
+}
 
+func (i *InteractionBox) normalizeInto(vec, position []float64, clamp bool) {
 	vec[0] = ((position[0] - float64(i.Center[0])) / i.Size[0]) + 0.5
 	vec[1] = ((position[1] - float64(i.Center[1])) / i.Size[1]) + 0.5
 	vec[2] = ((position[2] - float64(i.Center[2])) / i.Size[2]) + 0.5
@@ -115,93 +240,502 @@ func (i *InteractionBox) NormalizePoint(position []float64, clamp bool) ([]float
 		vec[1] = math.Min(math.Max(vec[1], 0), 1)
 		vec[2] = math.Min(math.Max(vec[2], 0), 1)
 	}
-
-	return vec, nil
 }
 
 // Pointable represents a Pointable in a Frame
 type Pointable struct {
-	Bases                 []float64 `json:"bases"`
-	BtipPosition          []float64 `json:"btipPosition"`
-	CarpPosition          []float64 `json:"carpPosition"`
-	DipPosition           []float64 `json:"dipPosition"`
-	Direction             []float64 `json:"direction"`
-	Extended              bool      `json:"extended"`
-	HandID                int       `json:"handId"`
-	ID                    int       `json:"id"`
-	Length                float64   `json:"length"`
-	McpPosition           []float64 `json:"mcpPosition"`
-	PipPosition           []float64 `json:"pipPosition"`
-	StabilizedTipPosition []float64 `json:"stabilizedTipPosition"`
-	TimeVisible           float64   `json:"timeVisible"`
-	TipPosition           []float64 `json:"tipPosition"`
-	TipVelocity           []float64 `json:"tipVelocity"`
-	Tool                  bool      `json:"tool"`
-	TouchDistance         float64   `json:"touchDistance"`
-	TouchZone             string    `json:"touchZone"`
-	Type                  int       `json:"type"`
-	Width                 float64   `json:"width"`
+	Bases                 [][][]float64 `json:"bases"` // a 3x3 ArmBasis like matrix per bone, metacarpal first
+	BtipPosition          []float64     `json:"btipPosition"`
+	CarpPosition          []float64     `json:"carpPosition"`
+	DipPosition           []float64     `json:"dipPosition"`
+	Direction             []float64     `json:"direction"`
+	Extended              bool          `json:"extended"`
+	HandID                int           `json:"handId"`
+	ID                    int           `json:"id"`
+	Length                float64       `json:"length"`
+	McpPosition           []float64     `json:"mcpPosition"`
+	PipPosition           []float64     `json:"pipPosition"`
+	StabilizedTipPosition []float64     `json:"stabilizedTipPosition"`
+	TimeVisible           float64       `json:"timeVisible"`
+	TipPosition           []float64     `json:"tipPosition"`
+	TipVelocity           []float64     `json:"tipVelocity"`
+	Tool                  bool          `json:"tool"`
+	TouchDistance         float64       `json:"touchDistance"`
+	TouchZone             string        `json:"touchZone"`
+	Type                  FingerType    `json:"type"`
+	Width                 float64       `json:"width"`
 }
 
 // Client represents a connection to a Leap Motion WebSocket server
 type Client struct {
-	ws           *websocket.Conn
+	conn         Transport // guarded by mu, replaced on reconnect
+	address      string    // WebSocket URL of the service
 	frameHandler func(*Frame)
+	handler      func(*Frame) // frameHandler wrapped in the middleware
 	done         chan struct{}
+	closing      chan struct{} // closed by Close to stop the message loop
+	errs         chan error
+	finishOnce   sync.Once
+	opts         options
+
+	procMu sync.Mutex  // guards proc
+	proc   *processing // the running message loop, nil when stopped
+
+	sendMu sync.Mutex // serializes writes to conn
+
+	mu                sync.Mutex
+	closed            bool
+	draining          bool // the next message starts a drain to the latest
+	finished          bool // errs is closed
+	reconnecting      bool // reconnect is redialing
+	paused            bool
+	streaming         bool
+	backgroundGranted bool
+	handshake         Handshake
+	lastReceivedAt    time.Time
+	lastFrameID       float64
+	stats             Stats
+	boxCenter         []float64
+	boxSize           []float64
+	gestureParams     map[string]float64
+	configs           []configMessage // sent by the Set* helpers, in order
+	history           frameRing
+	lastSeen          map[int]time.Time // keyed by hand ID
+	tees              []io.Writer
+	devices           map[string]DeviceEvent // attached devices keyed by ID
+	health            healthSamples
+
+	grabs  grabWatcher
+	enters enterWatcher
+	subs   subscribers
+	pool   *workerPool // nil without WithWorkers
 }
 
 // Connect to WebSocket and pass a frameHandler that is called whenever the WebSocket
 // sends frame data
-func Connect(frameHandler func(frame *Frame)) (*Client, error) {
-	conn, err := websocket.Dial(defaultLeapWebSocketAddress, "", "http://localhost/")
-	if err != nil {
+func Connect(frameHandler func(frame *Frame), opts ...Option) (*Client, error) {
+	return ConnectTo(defaultLeapWebSocketAddress, frameHandler, opts...)
+}
+
+// ConnectTo is Connect to the Leap Motion service at address instead of the
+// local one, e.g. "ws://tracker.local:6437/v6.json" for a service running on
+// another machine. The address must be a ws or wss URL.
+func ConnectTo(address string, frameHandler func(frame *Frame), opts ...Option) (*Client, error) {
+	if err := checkAddress(address); err != nil {
+		return nil, err
+	}
+
+	c := newClient(frameHandler, opts)
+	c.address = address
+
+	if err := c.connect(); err != nil {
 		return nil, err
 	}
 
+	c.startProcessing() // loops until socket is closed
+
+	return c, nil
+}
+
+func newClient(frameHandler func(*Frame), opts []Option) *Client {
 	c := &Client{
-		ws:           conn,
 		done:         make(chan struct{}),
+		closing:      make(chan struct{}),
+		errs:         make(chan error, errorsBuffer),
 		frameHandler: frameHandler,
+		address:      defaultLeapWebSocketAddress,
 	}
 
-	// Enable gestures recognition from leap sensor
-	if err := websocket.JSON.Send(c.ws, map[string]bool{"enableGestures": true}); err != nil {
+	for _, opt := range opts {
+		opt(&c.opts)
+	}
+	if c.opts.frameHistory > 0 {
+		c.history.size = c.opts.frameHistory
+	}
+
+	c.handler = chain(c.opts.middleware, c.deliver)
+	if c.opts.workers > 0 && frameHandler != nil {
+		c.pool = newWorkerPool(c.opts.workers, c.opts.orderedWorkers, frameHandler)
+	}
+
+	return c
+}
+
+// ConnectContext is Connect with the client closed once ctx is done. It
+// returns ctx.Err() without connecting if ctx is done already.
+func ConnectContext(ctx context.Context, frameHandler func(frame *Frame), opts ...Option) (*Client, error) {
+	if err := ctx.Err(); err != nil {
 		return nil, err
 	}
 
-	// Enable our application to run in the background and receive messages
-	if err := websocket.JSON.Send(c.ws, map[string]bool{"backgroundMessage": true}); err != nil {
+	c, err := Connect(frameHandler, opts...)
+	if err != nil {
 		return nil, err
 	}
 
-	go c.processData() // loops until socket is closed
+	// Done is closed when the client ends otherwise, so this never leaks
+	go func() {
+		select {
+		case <-ctx.Done():
+			c.Close()
+		case <-c.done:
+		}
+	}()
 
 	return c, nil
 }
 
-func (c *Client) processData() {
-	defer close(c.done)
-	data := &Frame{}
-	for {
-		if err := websocket.JSON.Receive(c.ws, data); err != nil {
-			continue
+// checkAddress returns an error wrapping ErrInvalidAddress unless address is a
+// ws or wss URL
+func checkAddress(address string) error {
+	if address == "" {
+		return fmt.Errorf("%w: address isn't set", ErrInvalidAddress)
+	}
+
+	u, err := url.Parse(address)
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidAddress, err)
+	}
+	if u.Scheme != "ws" && u.Scheme != "wss" {
+		return fmt.Errorf("%w: scheme of %q isn't ws or wss", ErrInvalidAddress, address)
+	}
+	if u.Host == "" {
+		return fmt.Errorf("%w: %q has no host", ErrInvalidAddress, address)
+	}
+	return nil
+}
+
+// deliver is the last stage of frame processing: it updates the event state
+// machines and hands the frame to the frameHandler
+func (c *Client) deliver(frame *Frame) {
+	if !frame.Synthetic {
+		c.mu.Lock()
+		c.health.deliver(time.Now())
+		c.mu.Unlock()
+	}
+
+	// An idle frame says nothing about the hands, so it mustn't end grabs or
+	// make the hands of the next frame enter again
+	if !frame.Synthetic {
+		c.grabs.update(frame)
+		c.enters.update(frame)
+	}
+	c.subs.publish(frame)
+
+	if c.pool != nil {
+		c.pool.submit(frame)
+	} else if c.frameHandler != nil {
+		c.frameHandler(frame)
+	}
+}
+
+// connect dials the WebSocket, sends the setup messages and runs the
+// onConnect callback. The socket is closed again if any step after dialing
+// fails. It's called by Connect and again by reconnect.
+func (c *Client) connect() error {
+	dial := c.opts.dialer
+	if dial == nil {
+		dial = func(address string) (Transport, error) {
+			return dialWebSocketConfig(address, c.opts.header, c.opts.protocols)
+		}
+	}
+
+	conn, err := dial(c.address)
+	if err != nil {
+		return &ConnectError{Phase: PhaseDial, Err: err}
+	}
+
+	c.mu.Lock()
+	closed := c.closed
+	if !closed {
+		c.conn = conn
+	}
+	c.mu.Unlock()
+	if closed {
+		conn.Close()
+		return ErrClosed
+	}
+
+	if err := c.setup(); err != nil {
+		conn.Close()
+		return err
+	}
+
+	return nil
+}
+
+func (c *Client) setup() error {
+	// Enable gestures recognition from leap sensor
+	if !c.opts.noGestures {
+		if err := c.send(map[string]bool{"enableGestures": true}); err != nil {
+			return &ConnectError{Phase: PhaseEnableGestures, Err: err}
+		}
+	}
+
+	// Enable our application to run in the background and receive messages
+	if !c.opts.noBackground {
+		if err := c.send(map[string]bool{"backgroundMessage": true}); err != nil {
+			return &ConnectError{Phase: PhaseBackgroundMessage, Err: err}
+		}
+	}
+
+	// Restore the configuration applied with the Set* helpers after a
+	// reconnect
+	if err := c.replayConfig(); err != nil {
+		return &ConnectError{Phase: PhaseReplay, Err: err}
+	}
+
+	if c.opts.onConnect != nil {
+		if err := c.opts.onConnect(c); err != nil {
+			return &ConnectError{Phase: PhaseOnConnect, Err: err}
+		}
+	}
+
+	return nil
+}
+
+// send writes v to the WebSocket as JSON
+func (c *Client) send(v interface{}) error {
+	c.mu.Lock()
+	closed, conn := c.closed, c.conn
+	c.mu.Unlock()
+	if closed {
+		return ErrClosed
+	}
+	if conn == nil {
+		return ErrNotConnected
+	}
+
+	msg, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+
+	c.sendMu.Lock()
+	defer c.sendMu.Unlock()
+
+	return conn.Send(msg)
+}
+
+// transport returns the current connection
+func (c *Client) transport() Transport {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.conn
+}
+
+// isClosed reports whether Close has been called
+func (c *Client) isClosed() bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.closed
+}
+
+// handleMessage routes a message from the WebSocket to the device event or
+// frame handling. It reports whether the message was a frame.
+func (c *Client) handleMessage(raw []byte) bool {
+	return c.handleMessageAt(raw, time.Now())
+}
+
+// handleMessageAt handles a message that was received at the given time
+func (c *Client) handleMessageAt(raw []byte, at time.Time) bool {
+	frame, isFrame := c.readMessage(raw, at)
+	if frame != nil {
+		c.handleFrame(frame)
+	}
+	return isFrame
+}
+
+// readMessage decodes a message and updates the client state from it. It
+// returns the frame to hand to the frameHandler, if any, and whether the
+// message was a frame.
+func (c *Client) readMessage(raw []byte, at time.Time) (*Frame, bool) {
+	if c.opts.decoder != nil {
+		decoded, err := c.opts.decoder(raw)
+		if err != nil {
+			c.recordDecodeError(at)
+			return nil, false
+		}
+		raw = decoded
+	}
+	c.tee(raw)
+
+	frame, msg, err := c.decodeMessage(raw)
+	if err != nil {
+		c.recordDecodeError(at)
+		if truncated(raw) {
+			c.mu.Lock()
+			c.stats.Truncated++
+			c.mu.Unlock()
+			c.reportError(fmt.Errorf("%w: %d bytes", ErrTruncatedMessage, len(raw)))
+		} else if c.opts.strict {
+			c.reportError(err)
 		}
+		return nil, false
+	}
+
+	if msg.Event != nil {
+		if msg.Event.Type == deviceEventType {
+			c.handleDeviceEvent(&msg.Event.State)
+		}
+		return nil, false
+	}
+
+	// The handshake isn't a frame, don't hand it to the frameHandler
+	if msg.Version != 0 || msg.ServiceVersion != "" {
+		c.mu.Lock()
+		c.handshake = msg.Handshake
+		c.mu.Unlock()
+		return nil, false
+	}
 
-		if c.frameHandler != nil {
-			c.frameHandler(data)
+	if msg.Background != nil {
+		c.mu.Lock()
+		c.backgroundGranted = *msg.Background
+		c.mu.Unlock()
+		return nil, false
+	}
+
+	if c.opts.strict {
+		if err := c.checkFrame(frame); err != nil {
+			c.recordDecodeError(at)
+			c.reportError(err)
+			return nil, false
 		}
 	}
+
+	frame.ReceivedAt = at
+	insane := c.opts.sanityLimit > 0 && !saneFrame(frame, c.opts.sanityLimit)
+
+	c.mu.Lock()
+	c.stats.Frames++
+	c.health.add(healthSample{at: at, id: frame.ID, fps: frame.CurrentFrameRate})
+	if insane {
+		c.stats.Rejected++
+		c.mu.Unlock()
+		return nil, true
+	}
+	if c.opts.dedup && c.stats.Frames > 1 && frame.ID == c.lastFrameID {
+		c.stats.Duplicates++
+		c.mu.Unlock()
+		return nil, true
+	}
+	c.lastFrameID = frame.ID
+
+	// Frames are only sent while the service is streaming
+	c.lastReceivedAt = at
+	c.streaming = true
+	c.updateInteractionBox(&frame.InteractionBox)
+	if c.history.size > 0 {
+		c.history.push(frame.Clone())
+	}
+	c.updateLastSeen(frame, at)
+	c.mu.Unlock()
+
+	return frame, true
 }
 
-// Close the websocket and stop processData for loop
+// handleFrame passes frame through the middleware to the frameHandler unless
+// the client is paused
+func (c *Client) handleFrame(frame *Frame) {
+	c.mu.Lock()
+	paused, closed := c.paused, c.closed
+	c.mu.Unlock()
+
+	if !paused && !closed && c.handler != nil {
+		c.handler(frame)
+	}
+}
+
+// Close the websocket and stop processData for loop. Done is closed before
+// Close returns and the message loop exits without waiting for Receive to
+// fail. No frame arriving during or after Close reaches the frameHandler,
+// except one already being handled, or with WithWorkers the frames queued for
+// the workers, which Close waits for.
+// Using the client after Close returns ErrClosed.
 func (c *Client) Close() error {
-	if c.ws == nil {
+	c.mu.Lock()
+	closed, conn := c.closed, c.conn
+	c.closed = true
+	c.mu.Unlock()
+
+	if closed {
+		return nil
+	}
+
+	close(c.closing)
+	c.finish()
+	if c.pool != nil {
+		c.pool.close()
+	}
+
+	if conn == nil {
 		return nil
 	}
-	return c.ws.Close()
+	return conn.Close()
+}
+
+// Errors returns a read only channel errors are reported on: failed reads,
+// reconnects and Tee writes, and with WithStrictDecode malformed messages. It
+// buffers up to 16 errors nobody has read, keeping the oldest: errors reported
+// while it's full are dropped, so an unread channel never blocks the message
+// loop. It is closed together with Done, by Close or when the connection
+// ends, so
+//
+//	for err := range c.Errors() {
+//	}
+//
+// terminates. Errors still buffered can be read after it's closed.
+func (c *Client) Errors() <-chan error {
+	return c.errs
+}
+
+// reportError sends err on the Errors channel unless it is full or closed
+func (c *Client) reportError(err error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.finished {
+		return
+	}
+	select {
+	case c.errs <- err:
+	default:
+	}
 }
 
 // Done returns a read only channel to know when the client is closed
 func (c *Client) Done() <-chan struct{} {
 	return c.done
 }
+
+// LastReceivedAt returns the host wall clock time the latest frame was
+// received at, or the zero time if no frame has been received
+func (c *Client) LastReceivedAt() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.lastReceivedAt
+}
+
+// Pause stops frames from being handed to the frameHandler without
+// disconnecting. Messages are still read from the socket so it doesn't back up.
+func (c *Client) Pause() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.paused = true
+}
+
+// Resume hands frames to the frameHandler again after Pause. With
+// WithDrainOnResume, the messages queued when the next one is processed are
+// skipped up to the most recent one.
+func (c *Client) Resume() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.paused = false
+	c.draining = c.opts.drainOnResume
+}
@@ -1,7 +1,10 @@
 package leapmotion
 
 import (
+	"context"
+	"errors"
 	"fmt"
+	"math"
 	"testing"
 	"time"
 )
@@ -56,3 +59,360 @@ func TestNormalizePoint(t *testing.T) {
 		}
 	}
 }
+
+func TestNormalizePointInto(t *testing.T) {
+	interactionBox := InteractionBox{
+		Center: []int{1, 1, 1},
+		Size:   []float64{1, 1, 1},
+	}
+
+	position := []float64{1, 1.25, 2}
+	expected, err := interactionBox.NormalizePoint(position, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	dst := make([]float64, 3)
+	if err := interactionBox.NormalizePointInto(dst, position, true); err != nil {
+		t.Fatal(err)
+	}
+	for i := range expected {
+		if dst[i] != expected[i] {
+			t.Fatalf("Received %f. Expected %f", dst, expected)
+		}
+	}
+
+	if err := interactionBox.NormalizePointInto(make([]float64, 2), position, true); err == nil {
+		t.Fatal("Expected an error for a dst without enough values")
+	}
+
+	allocs := testing.AllocsPerRun(100, func() {
+		interactionBox.NormalizePointInto(dst, position, true)
+	})
+	if allocs != 0 {
+		t.Fatalf("Received %f allocations. Expected 0", allocs)
+	}
+}
+
+func BenchmarkNormalizePoint(b *testing.B) {
+	interactionBox := InteractionBox{Center: []int{0, 200, 0}, Size: []float64{235, 235, 147}}
+	position := []float64{20, 180, -30}
+
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		interactionBox.NormalizePoint(position, true)
+	}
+}
+
+func BenchmarkNormalizePointInto(b *testing.B) {
+	interactionBox := InteractionBox{Center: []int{0, 200, 0}, Size: []float64{235, 235, 147}}
+	position := []float64{20, 180, -30}
+	dst := make([]float64, 3)
+
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		interactionBox.NormalizePointInto(dst, position, true)
+	}
+}
+
+func TestNormalizePoints(t *testing.T) {
+	interactionBox := InteractionBox{
+		Center: []int{1, 1, 1},
+		Size:   []float64{1, 1, 1},
+	}
+
+	positions := [][]float64{{1, 1, 1}, {1.25, 0.75, 1}}
+	expected := [][]float64{{0.5, 0.5, 0.5}, {0.75, 0.25, 0.5}}
+
+	normalized, err := interactionBox.NormalizePoints(positions, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for i := range expected {
+		for j, p := range normalized[i] {
+			if p != expected[i][j] {
+				t.Fatalf("Received %f. Expected %f", normalized, expected)
+			}
+		}
+	}
+
+	if _, err := interactionBox.NormalizePoints([][]float64{{1, 1, 1}, {1}}, false); err == nil {
+		t.Fatal("Expected an error for a position without enough values")
+	}
+}
+
+func TestToNDC(t *testing.T) {
+	interactionBox := InteractionBox{
+		Center: []int{0, 200, 0},
+		Size:   []float64{200, 200, 200},
+	}
+
+	tests := []struct {
+		position []float64
+		clamp    bool
+		expected []float64
+	}{
+		{[]float64{0, 200, 0}, false, []float64{0, 0, 0}},
+		{[]float64{-100, 300, 50}, false, []float64{-1, 1, 0.5}},
+		{[]float64{200, 0, 0}, true, []float64{1, -1, 0}},
+	}
+
+	for _, test := range tests {
+		ndc, err := interactionBox.ToNDC(test.position, test.clamp)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		for i, p := range ndc {
+			if p != test.expected[i] {
+				t.Fatalf("Received %f. Expected %f", ndc, test.expected)
+			}
+		}
+	}
+}
+
+func TestInteractionBoxDimensions(t *testing.T) {
+	box := InteractionBox{Size: []float64{235, 240, 147}}
+	if box.Width() != 235 || box.Height() != 240 || box.Depth() != 147 {
+		t.Fatalf("Received %f %f %f. Expected %f", box.Width(), box.Height(), box.Depth(), box.Size)
+	}
+
+	empty := InteractionBox{}
+	if empty.Width() != 0 || empty.Height() != 0 || empty.Depth() != 0 {
+		t.Fatal("Expected 0 dimensions for a box without a size")
+	}
+}
+
+func TestPauseResume(t *testing.T) {
+	received := 0
+	c := newClient(func(*Frame) { received++ }, nil)
+
+	c.handleMessage([]byte(`{"id": 1}`))
+	c.Pause()
+	c.handleMessage([]byte(`{"id": 2}`))
+	c.Resume()
+	c.handleMessage([]byte(`{"id": 3}`))
+
+	if received != 2 {
+		t.Fatalf("Received %d frames. Expected 2", received)
+	}
+}
+
+func TestDrainOnResume(t *testing.T) {
+	transport := newFakeTransport(`{"id": 1}`)
+
+	release := make(chan struct{})
+	frames := make(chan *Frame, 10)
+	c, err := Connect(func(frame *Frame) {
+		frames <- frame
+		if frame.ID == 1 {
+			<-release
+		}
+	}, WithDialer(transport.dialer()), WithDrainOnResume(true))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer c.Close()
+
+	<-frames
+
+	// Frames queue up behind the blocked frameHandler
+	for id := 2; id <= 6; id++ {
+		transport.messages <- []byte(fmt.Sprintf(`{"id": %d}`, id))
+	}
+	time.Sleep(20 * time.Millisecond)
+
+	c.Pause()
+	c.Resume()
+	close(release)
+
+	select {
+	case frame := <-frames:
+		if frame.ID != 6 {
+			t.Fatalf("Received frame %v. Expected the latest frame 6", frame.ID)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("Timed out waiting for a frame")
+	}
+
+	if stats := c.Stats(); stats.Frames != 6 {
+		t.Fatalf("Received %d frames. Expected the skipped frames to be counted", stats.Frames)
+	}
+}
+
+func TestReceivedAt(t *testing.T) {
+	var frame *Frame
+	c := newClient(func(f *Frame) { frame = f }, nil)
+
+	at := time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC)
+	c.handleMessageAt([]byte(`{"id": 1}`), at)
+
+	if !frame.ReceivedAt.Equal(at) {
+		t.Fatalf("Received %v. Expected %v", frame.ReceivedAt, at)
+	}
+	if !c.LastReceivedAt().Equal(at) {
+		t.Fatalf("Received %v. Expected %v", c.LastReceivedAt(), at)
+	}
+}
+
+func TestNormalizePointDegenerateBox(t *testing.T) {
+	interactionBox := InteractionBox{
+		Center: []int{0, 200, 0},
+		Size:   []float64{200, 0, 200},
+	}
+
+	if _, err := interactionBox.NormalizePoint([]float64{0, 200, 0}, true); err != ErrDegenerateBox {
+		t.Fatalf("Received %v. Expected ErrDegenerateBox", err)
+	}
+}
+
+func TestCloseStopsProcessing(t *testing.T) {
+	transport := newFakeTransport()
+
+	frames := make(chan *Frame, 10)
+	c, err := Connect(func(frame *Frame) {
+		frames <- frame
+	}, WithDialer(transport.dialer()))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	c.Close()
+
+	select {
+	case <-c.Done():
+	default:
+		t.Fatal("Expected Done to be closed when Close returns")
+	}
+	if _, ok := <-c.Errors(); ok {
+		t.Fatal("Expected Errors to be closed when Close returns")
+	}
+
+	// Frames arriving after Close are dropped
+	transport.messages <- []byte(`{"id": 1}`)
+	select {
+	case frame := <-frames:
+		t.Fatalf("Received %v after Close", frame)
+	case <-time.After(20 * time.Millisecond):
+	}
+
+	if err := c.Close(); err != nil {
+		t.Fatalf("Received %v. Expected closing twice to be a no-op", err)
+	}
+}
+
+func TestConnectTo(t *testing.T) {
+	transport := newFakeTransport()
+
+	var dialed string
+	dial := func(address string) (Transport, error) {
+		dialed = address
+		return transport, nil
+	}
+
+	address := "ws://tracker.local:6437/v6.json"
+	c, err := ConnectTo(address, nil, WithDialer(dial))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer c.Close()
+
+	if dialed != address {
+		t.Fatalf("Received %q. Expected %q", dialed, address)
+	}
+
+	for _, invalid := range []string{"", "http://tracker.local:6437/v6.json", "tracker.local:6437", "ws:///v6.json", "ws://%zz"} {
+		if _, err := ConnectTo(invalid, nil, WithDialer(dial)); !errors.Is(err, ErrInvalidAddress) {
+			t.Fatalf("Received %v for %q. Expected ErrInvalidAddress", err, invalid)
+		}
+	}
+}
+
+func TestConnectContext(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	c, err := ConnectContext(ctx, nil, WithDialer(newFakeTransport().dialer()))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cancel()
+	select {
+	case <-c.Done():
+	case <-time.After(time.Second):
+		t.Fatal("Timed out waiting for Done after cancelling the context")
+	}
+	if err := c.send(map[string]bool{"focused": true}); err != ErrClosed {
+		t.Fatalf("Received %v. Expected ErrClosed", err)
+	}
+
+	if _, err := ConnectContext(ctx, nil, WithDialer(newFakeTransport().dialer())); err != context.Canceled {
+		t.Fatalf("Received %v. Expected context.Canceled", err)
+	}
+}
+
+func TestDenormalizePoint(t *testing.T) {
+	interactionBox := InteractionBox{
+		Center: []int{0, 200, 0},
+		Size:   []float64{235, 235, 147},
+	}
+
+	positions := [][]float64{{0, 200, 0}, {-40.5, 130.25, 60}, {117.5, 317.5, -73.5}}
+	for _, position := range positions {
+		normalized, err := interactionBox.NormalizePoint(position, false)
+		if err != nil {
+			t.Fatal(err)
+		}
+		denormalized, err := interactionBox.DenormalizePoint(normalized)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		for k := range position {
+			if math.Abs(denormalized[k]-position[k]) > 1e-9 {
+				t.Fatalf("Received %f. Expected %f", denormalized, position)
+			}
+		}
+	}
+
+	if _, err := interactionBox.DenormalizePoint([]float64{0.5, 0.5}); !errors.Is(err, ErrInvalidVector) {
+		t.Fatalf("Received %v. Expected ErrInvalidVector", err)
+	}
+	if _, err := (&InteractionBox{}).DenormalizePoint([]float64{0.5, 0.5, 0.5}); !errors.Is(err, ErrInvalidBox) {
+		t.Fatalf("Received %v. Expected ErrInvalidBox", err)
+	}
+}
+
+func TestWithOnConnect(t *testing.T) {
+	transport := newFakeTransport()
+
+	var setup []string
+	c, err := Connect(nil, WithDialer(transport.dialer()), WithOnConnect(func(*Client) error {
+		setup = transport.sentMessages()
+		return nil
+	}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	c.Close()
+
+	// The callback runs once the setup messages are sent
+	if len(setup) != 2 {
+		t.Fatalf("Received %v. Expected the 2 setup messages sent before the callback", setup)
+	}
+
+	transport = newFakeTransport()
+	failed := errors.New("subscribe failed")
+	_, err = Connect(nil, WithDialer(transport.dialer()), WithOnConnect(func(*Client) error {
+		return failed
+	}))
+	var ce *ConnectError
+	if !errors.As(err, &ce) || ce.Phase != PhaseOnConnect || !errors.Is(err, failed) {
+		t.Fatalf("Received %v. Expected a ConnectError in phase %s", err, PhaseOnConnect)
+	}
+	select {
+	case <-transport.closed:
+	default:
+		t.Fatal("Expected the transport to be closed when the callback fails")
+	}
+}
@@ -0,0 +1,33 @@
+package leapmotion
+
+import "fmt"
+
+// FlattenMatrix converts a 3x3 matrix field of a frame, such as Hand.R, whose
+// slices are the rows of the matrix, to the flat column-major array graphics
+// APIs expect: the first three values are the first column. It returns
+// ErrInvalidMatrix if r isn't 3x3.
+func FlattenMatrix(r [][]float64) ([9]float64, error) {
+	var flat [9]float64
+	if len(r) != 3 {
+		return flat, fmt.Errorf("%w: %d rows, expected 3", ErrInvalidMatrix, len(r))
+	}
+	for row := range r {
+		if len(r[row]) != 3 {
+			return flat, fmt.Errorf("%w: row %d has %d values, expected 3", ErrInvalidMatrix, row, len(r[row]))
+		}
+		for col := 0; col < 3; col++ {
+			flat[col*3+row] = r[row][col]
+		}
+	}
+	return flat, nil
+}
+
+// UnflattenMatrix converts a flat column-major array back to a 3x3 matrix in
+// the row slices of a frame field. It's the inverse of FlattenMatrix.
+func UnflattenMatrix(flat [9]float64) [][]float64 {
+	r := make([][]float64, 3)
+	for row := range r {
+		r[row] = []float64{flat[row], flat[3+row], flat[6+row]}
+	}
+	return r
+}
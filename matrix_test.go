@@ -0,0 +1,37 @@
+package leapmotion
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestFlattenMatrix(t *testing.T) {
+	r := [][]float64{
+		{1, 2, 3},
+		{4, 5, 6},
+		{7, 8, 9},
+	}
+
+	flat, err := FlattenMatrix(r)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if expected := [9]float64{1, 4, 7, 2, 5, 8, 3, 6, 9}; flat != expected {
+		t.Fatalf("Received %v. Expected %v", flat, expected)
+	}
+
+	back := UnflattenMatrix(flat)
+	for row := range r {
+		for col := range r[row] {
+			if back[row][col] != r[row][col] {
+				t.Fatalf("Received %v. Expected %v", back, r)
+			}
+		}
+	}
+
+	for _, invalid := range [][][]float64{nil, {{1, 2, 3}, {4, 5, 6}}, {{1, 2, 3}, {4, 5}, {7, 8, 9}}} {
+		if _, err := FlattenMatrix(invalid); !errors.Is(err, ErrInvalidMatrix) {
+			t.Fatalf("Received %v for %v. Expected ErrInvalidMatrix", err, invalid)
+		}
+	}
+}
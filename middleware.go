@@ -0,0 +1,131 @@
+package leapmotion
+
+import (
+	"math"
+	"time"
+)
+
+// FrameMiddleware is a stage of frame processing. It is given the next stage
+// and returns a function that processes a frame and passes it on, or drops it
+// by not calling next. Stages are composed with WithMiddleware.
+type FrameMiddleware func(next func(*Frame)) func(*Frame)
+
+// chain wraps handler in the middleware so that the first stage runs first
+func chain(middleware []FrameMiddleware, handler func(*Frame)) func(*Frame) {
+	if handler == nil {
+		handler = func(*Frame) {}
+	}
+	for i := len(middleware) - 1; i >= 0; i-- {
+		handler = middleware[i](handler)
+	}
+	return handler
+}
+
+// FlipMiddleware returns a stage that negates the given axes of every frame.
+// See Frame.Flip.
+func FlipMiddleware(axes ...Axis) FrameMiddleware {
+	return func(next func(*Frame)) func(*Frame) {
+		return func(frame *Frame) {
+			next(frame.Flip(axes...))
+		}
+	}
+}
+
+// MotionThresholdMiddleware returns a stage that drops frames in which no
+// hand's palm has moved more than mm millimeters since the last frame passed
+// on. Frames where hands appear or disappear are always passed on.
+func MotionThresholdMiddleware(mm float64) FrameMiddleware {
+	return func(next func(*Frame)) func(*Frame) {
+		var last map[int][]float64 // palm positions of the last frame passed on
+
+		return func(frame *Frame) {
+			if frame.Synthetic {
+				next(frame)
+				return
+			}
+
+			moved := last == nil || len(frame.Hands) != len(last)
+			for _, h := range frame.Hands {
+				if moved {
+					break
+				}
+				p, ok := last[h.ID]
+				moved = !ok || (isVector(h.PalmPosition) && isVector(p) &&
+					distanceSquared(h.PalmPosition, p) > mm*mm)
+			}
+			if !moved {
+				return
+			}
+
+			last = make(map[int][]float64, len(frame.Hands))
+			for _, h := range frame.Hands {
+				last[h.ID] = copyVector(h.PalmPosition)
+			}
+			next(frame)
+		}
+	}
+}
+
+// SmoothingMiddleware returns a stage that smooths the palm and fingertip
+// positions of every frame with an exponential moving average whose weight
+// follows the time between frames, from their timestamps, rather than being
+// fixed per frame. A position takes about timeConstant to follow a move, the
+// same at 30 as at 120 frames per second. Positions of hands and fingers that
+// just appeared aren't smoothed.
+func SmoothingMiddleware(timeConstant time.Duration) FrameMiddleware {
+	return func(next func(*Frame)) func(*Frame) {
+		var lastTimestamp int
+		var palms, tips map[int][]float64 // smoothed positions keyed by ID
+
+		return func(frame *Frame) {
+			if frame.Synthetic {
+				next(frame)
+				return
+			}
+
+			// A timestamp going backward means the service restarted
+			dt := frame.Timestamp - lastTimestamp
+			if palms == nil || dt < 0 {
+				dt = 0
+				palms, tips = nil, nil
+			}
+			lastTimestamp = frame.Timestamp
+
+			alpha := 1.0
+			if tau := float64(timeConstant / time.Microsecond); tau > 0 {
+				alpha = 1 - math.Exp(-float64(dt)/tau)
+			}
+
+			smoothed := make(map[int][]float64, len(frame.Hands))
+			for i := range frame.Hands {
+				h := &frame.Hands[i]
+				smoothed[h.ID] = smoothVector(h.PalmPosition, palms[h.ID], alpha)
+			}
+			palms = smoothed
+
+			smoothed = make(map[int][]float64, len(frame.Pointables))
+			for i := range frame.Pointables {
+				p := &frame.Pointables[i]
+				smoothed[p.ID] = smoothVector(p.TipPosition, tips[p.ID], alpha)
+			}
+			tips = smoothed
+
+			next(frame)
+		}
+	}
+}
+
+// smoothVector moves v, in place, the fraction alpha of the way from last to
+// v, and returns a copy of the result as the next last. v is left as is if
+// last isn't set.
+func smoothVector(v, last []float64, alpha float64) []float64 {
+	if !isVector(v) {
+		return nil
+	}
+	if isVector(last) {
+		for k := 0; k < 3; k++ {
+			v[k] = last[k] + alpha*(v[k]-last[k])
+		}
+	}
+	return copyVector(v)
+}
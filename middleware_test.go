@@ -0,0 +1,97 @@
+package leapmotion
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+func TestMiddlewareOrder(t *testing.T) {
+	var stages []string
+	stage := func(name string) FrameMiddleware {
+		return func(next func(*Frame)) func(*Frame) {
+			return func(frame *Frame) {
+				stages = append(stages, name)
+				next(frame)
+			}
+		}
+	}
+	dropAll := func(next func(*Frame)) func(*Frame) {
+		return func(*Frame) {}
+	}
+
+	var received *Frame
+	c := newClient(func(frame *Frame) { received = frame }, []Option{
+		WithMiddleware(stage("first"), stage("second")),
+		WithCoordinateFlip(AxisZ),
+	})
+	c.handleMessage([]byte(`{"id": 1, "hands": [{"palmPosition": [0, 200, 10]}]}`))
+
+	if len(stages) != 2 || stages[0] != "first" || stages[1] != "second" {
+		t.Fatalf("Received stages %v. Expected [first second]", stages)
+	}
+	if received == nil || received.Hands[0].PalmPosition[2] != -10 {
+		t.Fatalf("Received %v. Expected the flipped frame", received)
+	}
+
+	received = nil
+	c = newClient(func(frame *Frame) { received = frame }, []Option{WithMiddleware(dropAll)})
+	c.handleMessage([]byte(`{"id": 1}`))
+	if received != nil {
+		t.Fatal("Expected the frame to be dropped")
+	}
+}
+
+func TestMotionThreshold(t *testing.T) {
+	var received []float64
+	handler := MotionThresholdMiddleware(5)(func(frame *Frame) {
+		received = append(received, frame.ID)
+	})
+
+	hand := func(x float64) []Hand {
+		return []Hand{{ID: 1, PalmPosition: []float64{x, 200, 0}}}
+	}
+
+	handler(&Frame{ID: 1, Hands: hand(0)})
+	handler(&Frame{ID: 2, Hands: hand(3)}) // still
+	handler(&Frame{ID: 3, Hands: hand(4)}) // still, measured from frame 1
+	handler(&Frame{ID: 4, Hands: hand(6)})
+	handler(&Frame{ID: 5})                 // hand left
+	handler(&Frame{ID: 6})                 // still empty
+	handler(&Frame{ID: 7, Hands: hand(6)}) // hand came back
+
+	expected := []float64{1, 4, 5, 7}
+	if len(received) != len(expected) {
+		t.Fatalf("Received frames %v. Expected %v", received, expected)
+	}
+	for i, id := range expected {
+		if received[i] != id {
+			t.Fatalf("Received frames %v. Expected %v", received, expected)
+		}
+	}
+}
+
+func TestSmoothingFrameRateIndependent(t *testing.T) {
+	// run steps a palm from x 0 to 100 and returns its smoothed x 100ms
+	// later at the given frame rate
+	run := func(fps int) float64 {
+		var x float64
+		handler := SmoothingMiddleware(50 * time.Millisecond)(func(frame *Frame) {
+			x = frame.Hands[0].PalmPosition[0]
+		})
+
+		handler(&Frame{Hands: []Hand{{ID: 1, PalmPosition: []float64{0, 200, 0}}}})
+		step := 1000000 / fps
+		for ts := step; ts <= 100000; ts += step {
+			handler(&Frame{Timestamp: ts, Hands: []Hand{{ID: 1, PalmPosition: []float64{100, 200, 0}}}})
+		}
+		return x
+	}
+
+	expected := 100 * (1 - math.Exp(-2))
+	for _, fps := range []int{40, 100, 200} {
+		if x := run(fps); math.Abs(x-expected) > 1e-6 {
+			t.Fatalf("Received %f at %d fps. Expected %f", x, fps, expected)
+		}
+	}
+}
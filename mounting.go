@@ -0,0 +1,108 @@
+package leapmotion
+
+import "context"
+
+// mountingSamples is the number of hands DetectMounting observes
+const mountingSamples = 30
+
+// mountingMargin is the distance in millimeters from the center of the
+// sensor within which the side of a hand is too ambiguous to count
+const mountingMargin = 20
+
+// Mounting is the orientation of the sensor inferred by DetectMounting
+type Mounting struct {
+	// SwappedHands is true when left hands are consistently seen right of
+	// the sensor and right hands left of it
+	SwappedHands bool
+	// PalmsUp is true when palms held down consistently point up
+	PalmsUp bool
+	// Inverted is true when both are the case, as for a sensor mounted
+	// upside down
+	Inverted bool
+	// Flip is the coordinate transform correcting the mounting, to pass to
+	// WithCoordinateFlip. It's empty when the sensor is mounted as expected.
+	Flip []Axis
+}
+
+// DetectMounting observes the hands of the next frames the client delivers to
+// infer how the sensor is mounted. The user should hold both hands flat, palms
+// down, over the sensor while it runs. The frames are seen after the
+// middleware, so a coordinate flip already configured is taken into account.
+// It returns once enough hands have been observed, with ctx.Err() if ctx is
+// done first or ErrClosed if the client is closed.
+func (c *Client) DetectMounting(ctx context.Context) (Mounting, error) {
+	frames, unsubscribe := c.subscribe()
+	defer unsubscribe()
+
+	var votes mountingVotes
+	for votes.samples < mountingSamples {
+		select {
+		case frame := <-frames:
+			votes.add(frame)
+		case <-ctx.Done():
+			return Mounting{}, ctx.Err()
+		case <-c.done:
+			return Mounting{}, ErrClosed
+		}
+	}
+
+	return votes.mounting(), nil
+}
+
+// mountingVotes counts the hands seen agreeing and disagreeing with a sensor
+// mounted desk up
+type mountingVotes struct {
+	samples      int
+	sides        int
+	wrongSides   int
+	normals      int
+	wrongNormals int
+}
+
+func (v *mountingVotes) add(frame *Frame) {
+	for _, hand := range frame.Hands {
+		if !isVector(hand.PalmPosition) || !isVector(hand.PalmNormal) {
+			continue
+		}
+		v.samples++
+
+		v.normals++
+		if hand.PalmNormal[1] > 0 {
+			v.wrongNormals++
+		}
+
+		x := hand.PalmPosition[0]
+		if x > -mountingMargin && x < mountingMargin {
+			continue
+		}
+		switch hand.Type {
+		case "left":
+			v.sides++
+			if x > 0 {
+				v.wrongSides++
+			}
+		case "right":
+			v.sides++
+			if x < 0 {
+				v.wrongSides++
+			}
+		}
+	}
+}
+
+// mounting returns the mounting the votes consistently, by two thirds, point
+// to
+func (v *mountingVotes) mounting() Mounting {
+	var m Mounting
+	if v.sides > 0 && 3*v.wrongSides >= 2*v.sides {
+		m.SwappedHands = true
+		m.Flip = append(m.Flip, AxisX)
+	}
+	if v.normals > 0 && 3*v.wrongNormals >= 2*v.normals {
+		m.PalmsUp = true
+		m.Flip = append(m.Flip, AxisY)
+	}
+	m.Inverted = m.SwappedHands && m.PalmsUp
+
+	return m
+}
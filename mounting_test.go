@@ -0,0 +1,66 @@
+package leapmotion
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+)
+
+func TestDetectMounting(t *testing.T) {
+	tests := []struct {
+		left, right string // palm x and normal y of each hand
+		expected    Mounting
+	}{
+		{`-100, 200, 0], "palmNormal": [0, -1, 0`, `100, 200, 0], "palmNormal": [0, -1, 0`, Mounting{}},
+		{`100, 200, 0], "palmNormal": [0, 1, 0`, `-100, 200, 0], "palmNormal": [0, 1, 0`,
+			Mounting{SwappedHands: true, PalmsUp: true, Inverted: true, Flip: []Axis{AxisX, AxisY}}},
+		{`100, 200, 0], "palmNormal": [0, -1, 0`, `-100, 200, 0], "palmNormal": [0, -1, 0`,
+			Mounting{SwappedHands: true, Flip: []Axis{AxisX}}},
+	}
+
+	for _, test := range tests {
+		c := newClient(nil, nil)
+
+		result := make(chan Mounting, 1)
+		go func() {
+			m, err := c.DetectMounting(context.Background())
+			if err != nil {
+				t.Error(err)
+			}
+			result <- m
+		}()
+
+		msg := fmt.Sprintf(`{"id": %%d, "hands": [{"id": 1, "type": "left", "palmPosition": [%s]}, {"id": 2, "type": "right", "palmPosition": [%s]}]}`, test.left, test.right)
+
+		var m Mounting
+		timeout := time.After(time.Second)
+	feed:
+		for id := 1; ; id++ {
+			c.handleMessage([]byte(fmt.Sprintf(msg, id)))
+			select {
+			case m = <-result:
+				break feed
+			case <-timeout:
+				t.Fatal("Timed out waiting for DetectMounting")
+			case <-time.After(time.Millisecond):
+			}
+		}
+
+		if m.SwappedHands != test.expected.SwappedHands || m.PalmsUp != test.expected.PalmsUp ||
+			m.Inverted != test.expected.Inverted || len(m.Flip) != len(test.expected.Flip) {
+			t.Fatalf("Received %+v. Expected %+v", m, test.expected)
+		}
+	}
+}
+
+func TestDetectMountingContext(t *testing.T) {
+	c := newClient(nil, nil)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	if _, err := c.DetectMounting(ctx); err != context.Canceled {
+		t.Fatalf("Received %v. Expected context.Canceled", err)
+	}
+}
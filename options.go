@@ -0,0 +1,310 @@
+package leapmotion
+
+import (
+	"net/http"
+	"time"
+)
+
+// Option configures a Client created by Connect
+type Option func(*options)
+
+type options struct {
+	dialer     DialFunc
+	onConnect  func(*Client) error
+	middleware []FrameMiddleware
+	boxAlpha   float64
+	fields     map[string]bool
+	decoder    func([]byte) ([]byte, error)
+	unmarshal  Unmarshaler
+	strict     bool
+	idle       time.Duration
+	dedup      bool
+	header     http.Header
+	protocols  []string
+
+	drainOnResume bool
+	noGestures    bool // don't send enableGestures
+	noBackground  bool // don't send backgroundMessage
+	onDevice      func(*DeviceEvent)
+	sanityLimit   float64 // 0 disables the sanity check
+
+	maxReceiveErrors int
+	reconnectRetries int
+	reconnectDelay   time.Duration
+	shouldReconnect  func(error) bool
+
+	workers        int
+	orderedWorkers bool
+	frameHistory   int
+}
+
+// WithDialer makes the Client connect through the Transport returned by d
+// instead of a golang.org/x/net/websocket connection
+func WithDialer(d DialFunc) Option {
+	return func(o *options) {
+		o.dialer = d
+	}
+}
+
+// WithHeader adds a header to the WebSocket handshake request, e.g.
+// WithHeader("Authorization", "Bearer "+token) for a bridge requiring
+// authentication. It's ignored when WithDialer is used.
+func WithHeader(key, value string) Option {
+	return func(o *options) {
+		if o.header == nil {
+			o.header = http.Header{}
+		}
+		o.header.Add(key, value)
+	}
+}
+
+// WithSubprotocol requests the given WebSocket subprotocols during the
+// handshake. It's ignored when WithDialer is used.
+func WithSubprotocol(protocols ...string) Option {
+	return func(o *options) {
+		o.protocols = append(o.protocols, protocols...)
+	}
+}
+
+// WithGestures sets whether gesture recognition is requested from the Leap
+// Motion service on connecting. It's on by default; turn it off for apps only
+// tracking hands, to spare the service the gesture recognition.
+func WithGestures(enabled bool) Option {
+	return func(o *options) {
+		o.noGestures = !enabled
+	}
+}
+
+// WithBackgroundMessages sets whether frames are requested while the
+// application isn't focused. It's on by default; turn it off to have the
+// service pause tracking for the app when it loses focus.
+func WithBackgroundMessages(enabled bool) Option {
+	return func(o *options) {
+		o.noBackground = !enabled
+	}
+}
+
+// WithDeviceHandler registers h to be called with every device event, e.g. to
+// show that the controller was unplugged or the service paused, alongside the
+// frames passed to the frameHandler
+func WithDeviceHandler(h func(*DeviceEvent)) Option {
+	return func(o *options) {
+		o.onDevice = h
+	}
+}
+
+// WithOnConnect registers f to be called once the WebSocket is connected and
+// the setup messages are sent, before any frame is handed to the frameHandler.
+// If f returns an error the connection is closed and Connect returns the error.
+func WithOnConnect(f func(*Client) error) Option {
+	return func(o *options) {
+		o.onConnect = f
+	}
+}
+
+// WithCoordinateFlip negates the given axes of every frame before it is handed
+// to the frameHandler. It adds a FlipMiddleware stage to the middleware chain.
+func WithCoordinateFlip(axes ...Axis) Option {
+	return WithMiddleware(FlipMiddleware(axes...))
+}
+
+// WithSmoothedInteractionBox makes Client.InteractionBox return an exponential
+// moving average of the interaction boxes of the received frames rather than
+// the box of the latest frame. alpha in (0..1] is the weight of each new frame;
+// smaller values smooth more.
+func WithSmoothedInteractionBox(alpha float64) Option {
+	return func(o *options) {
+		o.boxAlpha = alpha
+	}
+}
+
+// WithFields limits decoding of frames to the given top level JSON fields,
+// e.g. WithFields("hands", "interactionBox"), so no work is spent building the
+// pointables and gestures of every frame when only palm data is needed. The
+// "id" and "timestamp" fields are always decoded.
+func WithFields(fields ...string) Option {
+	return func(o *options) {
+		o.fields = map[string]bool{"id": true, "timestamp": true}
+		for _, field := range fields {
+			o.fields[field] = true
+		}
+	}
+}
+
+// WithMessageDecoder registers decode to be applied to every message before it
+// is parsed as JSON, e.g. to decompress messages relayed through a gzipping
+// proxy. Messages decode fails on are dropped.
+func WithMessageDecoder(decode func([]byte) ([]byte, error)) Option {
+	return func(o *options) {
+		o.decoder = decode
+	}
+}
+
+// WithMiddleware appends stages to the middleware chain frames pass through
+// before reaching the frameHandler. Stages run in the order they are added,
+// including the stages added by other options such as WithCoordinateFlip.
+func WithMiddleware(m ...FrameMiddleware) Option {
+	return func(o *options) {
+		o.middleware = append(o.middleware, m...)
+	}
+}
+
+// WithStrictDecode checks every frame for the vectors the tracking data format
+// requires. Frames that are malformed, e.g. by a buggy relay, are dropped and
+// a descriptive error is sent on the Errors channel instead of the frame
+// reaching the frameHandler zero filled.
+func WithStrictDecode(strict bool) Option {
+	return func(o *options) {
+		o.strict = strict
+	}
+}
+
+// WithIdleFrames hands an empty frame, with Synthetic set, to the frameHandler
+// whenever no frame has arrived for interval, so render loops keep ticking
+// when the stream stalls. OnRelease, OnClench and OnHandEnter skip idle frames.
+func WithIdleFrames(interval time.Duration) Option {
+	return func(o *options) {
+		o.idle = interval
+	}
+}
+
+// Unmarshaler decodes JSON data into v with the semantics of json.Unmarshal
+type Unmarshaler func(data []byte, v interface{}) error
+
+// WithUnmarshaler decodes messages with u instead of encoding/json, e.g.
+// jsoniter.ConfigCompatibleWithStandardLibrary.Unmarshal on constrained
+// hardware where decoding dominates
+func WithUnmarshaler(u Unmarshaler) Option {
+	return func(o *options) {
+		o.unmarshal = u
+	}
+}
+
+// WithMotionThreshold skips frames in which no hand has moved more than mm
+// millimeters since the last frame handed to the frameHandler. It adds a
+// MotionThresholdMiddleware stage to the middleware chain.
+func WithMotionThreshold(mm float64) Option {
+	return WithMiddleware(MotionThresholdMiddleware(mm))
+}
+
+// WithSmoothing smooths the palm and fingertip positions of every frame with
+// the given time constant. It adds a SmoothingMiddleware stage to the
+// middleware chain.
+func WithSmoothing(timeConstant time.Duration) Option {
+	return WithMiddleware(SmoothingMiddleware(timeConstant))
+}
+
+// WithDedup drops frames whose ID repeats the ID of the previous frame, as
+// sent by relays that resend frames. Dropped frames are counted in
+// Stats.Duplicates.
+func WithDedup(dedup bool) Option {
+	return func(o *options) {
+		o.dedup = dedup
+	}
+}
+
+// WithMaxReceiveErrors ends the connection once reading from it failed n times
+// in a row, instead of DefaultMaxReceiveErrors times, rather than retrying a
+// broken socket forever. An error wrapping ErrReceiveFailed is reported on the
+// Errors channel, then the client reconnects if WithReconnect is set or Done
+// is closed.
+func WithMaxReceiveErrors(n int) Option {
+	return func(o *options) {
+		o.maxReceiveErrors = n
+	}
+}
+
+// WithReconnect redials the Leap Motion service when the connection ends, up to
+// maxRetries times in a row, waiting baseDelay before the first attempt and
+// twice as long before every following one. The setup messages are sent, the
+// configuration applied with the Set* helpers, such as SetGestureParam, is
+// replayed in order and the WithOnConnect callback is run again on the new
+// connection, and frames keep going to the same frameHandler. Failed attempts
+// are reported on the Errors channel; Done is closed once the retries are
+// used up.
+func WithReconnect(maxRetries int, baseDelay time.Duration) Option {
+	return func(o *options) {
+		o.reconnectRetries = maxRetries
+		o.reconnectDelay = baseDelay
+	}
+}
+
+// WithShouldReconnect limits WithReconnect to the errors shouldReconnect
+// returns true for. It's passed the error that ended the connection and the
+// error of every failed attempt, so a permanent failure, e.g. a bridge
+// rejecting the handshake, ends the client instead of being retried.
+func WithShouldReconnect(shouldReconnect func(err error) bool) Option {
+	return func(o *options) {
+		o.shouldReconnect = shouldReconnect
+	}
+}
+
+// WithDrainOnResume makes Resume skip the frames that queued up between the
+// socket and processData, e.g. behind a slow frameHandler, so the first frame
+// handed to the frameHandler after Resume is the freshest one instead of a
+// burst of stale ones. processData keeps reading while paused, so the socket
+// doesn't back up during the pause itself. Skipped frames still update the
+// client state, such as Stats and History, and messages other than frames
+// are never skipped.
+func WithDrainOnResume(drain bool) Option {
+	return func(o *options) {
+		o.drainOnResume = drain
+	}
+}
+
+// WithSanityCheck drops frames holding impossible values, such as a corrupted
+// frame with NaN or 1e30 positions, before they reach the frameHandler and
+// wreck smoothing filters. A frame is dropped when any of its vectors has a
+// NaN or infinite component, or a position is further than DefaultSanityLimit
+// from the origin. Dropped frames are counted in Stats.Rejected.
+func WithSanityCheck(check bool) Option {
+	return func(o *options) {
+		if !check {
+			o.sanityLimit = 0
+		} else if o.sanityLimit == 0 {
+			o.sanityLimit = DefaultSanityLimit
+		}
+	}
+}
+
+// WithSanityLimit enables WithSanityCheck with positions limited to mm
+// millimeters from the origin instead of DefaultSanityLimit
+func WithSanityLimit(mm float64) Option {
+	return func(o *options) {
+		o.sanityLimit = mm
+	}
+}
+
+// WithWorkers runs the frameHandler on a pool of n goroutines, so a handler
+// doing heavy stateless work per frame can use several cores. Handlers then
+// run concurrently and may complete out of order; WithOrderedWorkers makes
+// them run in the order the frames arrived. Middleware and the event
+// callbacks, such as OnRelease, still run in order on the message loop. Close
+// waits for the queued frames to be handled, so it must not be called from
+// the frameHandler.
+func WithWorkers(n int) Option {
+	return func(o *options) {
+		o.workers = n
+	}
+}
+
+// WithOrderedWorkers is WithWorkers with the frameHandler run for every frame
+// only after it returned for the previous one, so handlers complete, and
+// apply their effects, in frame order. The handlers then run one at a time,
+// but off the message loop, so a slow handler doesn't hold up receiving
+// until the queue of frames is full.
+func WithOrderedWorkers(n int) Option {
+	return func(o *options) {
+		o.workers = n
+		o.orderedWorkers = true
+	}
+}
+
+// WithFrameHistory makes the client retain the latest n frames for History,
+// e.g. for smoothing or velocities over several frames. Every frame received
+// is copied into the history, so by default, or with n = 0, none is kept.
+func WithFrameHistory(n int) Option {
+	return func(o *options) {
+		o.frameHistory = n
+	}
+}
@@ -0,0 +1,125 @@
+package leapmotion
+
+import (
+	"math"
+	"time"
+)
+
+// Defaults of a PalmFlipDetector
+const (
+	// DefaultPalmHysteresis is the angle in degrees on either side of
+	// sideways a palm must turn past to count as up or down
+	DefaultPalmHysteresis = 30
+	// DefaultPalmGrace is how long the orientation of a hand that dropped out
+	// of tracking is remembered
+	DefaultPalmGrace = 250 * time.Millisecond
+)
+
+// PalmFlipDetector recognizes a hand turning its palm up or down, e.g. to open
+// a menu with the palm up and close it with the palm down. The palm is up once
+// its normal is within 90-Hysteresis degrees of +Y and down once it's within
+// 90-Hysteresis degrees of -Y; in between the hand keeps its orientation, so
+// a palm held near sideways doesn't flicker. The first orientation of a hand
+// fires too. A hand missing for less than Grace keeps its orientation. Feed
+// it every frame with Update.
+type PalmFlipDetector struct {
+	// Hysteresis is the angle in degrees past sideways a palm must turn
+	Hysteresis float64
+	// Grace is how long a missing hand keeps its orientation
+	Grace time.Duration
+
+	detector[*palmState]
+	onUp   func(handID int)
+	onDown func(handID int)
+}
+
+type palmState struct {
+	up       bool
+	known    bool
+	lastSeen int // frame timestamp (microseconds)
+}
+
+// NewPalmFlipDetector returns a PalmFlipDetector with the default hysteresis
+// and grace period
+func NewPalmFlipDetector() *PalmFlipDetector {
+	return &PalmFlipDetector{
+		Hysteresis: DefaultPalmHysteresis,
+		Grace:      DefaultPalmGrace,
+	}
+}
+
+// OnPalmUp registers cb to be called with the ID of a hand that turned its
+// palm up
+func (d *PalmFlipDetector) OnPalmUp(cb func(handID int)) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	d.onUp = cb
+}
+
+// OnPalmDown registers cb to be called with the ID of a hand that turned its
+// palm down
+func (d *PalmFlipDetector) OnPalmDown(cb func(handID int)) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	d.onDown = cb
+}
+
+// Update feeds frame to the detector, calling the OnPalmUp and OnPalmDown
+// callbacks for every hand whose orientation changed
+func (d *PalmFlipDetector) Update(frame *Frame) {
+	var up, down []int
+
+	d.mu.Lock()
+	if !d.next(frame) {
+		d.mu.Unlock()
+		return
+	}
+
+	// cos of the angle from +Y within which the palm is up, or from -Y
+	// within which it's down
+	threshold := math.Cos((90 - d.Hysteresis) * math.Pi / 180)
+
+	for _, hand := range frame.Hands {
+		st := d.hands.get(hand.ID, func() *palmState { return &palmState{} })
+		st.lastSeen = frame.Timestamp
+
+		if !isVector(hand.PalmNormal) {
+			continue
+		}
+		normal := normalize(hand.PalmNormal)
+		if normal == nil {
+			continue
+		}
+
+		switch {
+		case normal[1] >= threshold && (!st.known || !st.up):
+			st.known, st.up = true, true
+			up = append(up, hand.ID)
+		case normal[1] <= -threshold && (!st.known || st.up):
+			st.known, st.up = true, false
+			down = append(down, hand.ID)
+		}
+	}
+
+	grace := int(d.Grace / time.Microsecond)
+	for id, st := range d.hands {
+		if frame.Timestamp-st.lastSeen > grace {
+			delete(d.hands, id)
+		}
+	}
+	onUp, onDown := d.onUp, d.onDown
+	d.mu.Unlock()
+
+	if onUp != nil {
+		for _, id := range up {
+			onUp(id)
+		}
+	}
+	if onDown != nil {
+		for _, id := range down {
+			onDown(id)
+		}
+	}
+}
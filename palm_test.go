@@ -0,0 +1,50 @@
+package leapmotion
+
+import (
+	"math"
+	"testing"
+)
+
+func TestPalmFlipDetector(t *testing.T) {
+	d := NewPalmFlipDetector()
+
+	var events []string
+	d.OnPalmUp(func(int) { events = append(events, "up") })
+	d.OnPalmDown(func(int) { events = append(events, "down") })
+
+	// normal returns a palm normal turned degrees from -Y (palm down) to +Y
+	normal := func(degrees float64) []float64 {
+		rad := degrees * math.Pi / 180
+		return []float64{math.Sin(rad), -math.Cos(rad), 0}
+	}
+	frame := func(id int, degrees float64) *Frame {
+		return testFrame(id, id*10000, Hand{ID: 1, PalmNormal: normal(degrees)})
+	}
+
+	angles := []float64{
+		0,   // down, the first orientation fires
+		80,  // near sideways, no change
+		100, // past sideways but within the hysteresis
+		130, // up
+		100, // back within the hysteresis, still up
+		170,
+		40, // down
+	}
+	for i, angle := range angles {
+		d.Update(frame(i+1, angle))
+	}
+
+	// A brief dropout keeps the orientation
+	d.Update(testFrame(8, 80000))
+	d.Update(frame(9, 10))
+
+	expected := []string{"down", "up", "down"}
+	if len(events) != len(expected) {
+		t.Fatalf("Received %v. Expected %v", events, expected)
+	}
+	for i := range expected {
+		if events[i] != expected[i] {
+			t.Fatalf("Received %v. Expected %v", events, expected)
+		}
+	}
+}
@@ -0,0 +1,68 @@
+package leapmotion
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+)
+
+// UnmarshalJSON decodes a finger type from the numeric type of the tracking
+// data format or from a string, as some service versions send it: a number in
+// quotes, or "finger" or "tool" for a type the service doesn't tell, which is
+// FingerUnknown. null leaves the type unchanged.
+func (t *FingerType) UnmarshalJSON(data []byte) error {
+	raw := string(data)
+	if raw == "null" {
+		return nil
+	}
+
+	if unquoted, err := strconv.Unquote(raw); err == nil {
+		n, err := strconv.Atoi(unquoted)
+		if err != nil {
+			n = int(FingerUnknown)
+		}
+		*t = FingerType(n)
+		return nil
+	}
+
+	n, err := strconv.Atoi(raw)
+	if err != nil {
+		return fmt.Errorf("invalid finger type %s", raw)
+	}
+	*t = FingerType(n)
+	return nil
+}
+
+// ExtensionAngle returns how far the finger is bent, in degrees: the sum of
+// the angles between its proximal and intermediate bones, at the PIP joint,
+// and between its intermediate and distal bones, at the DIP joint. It's 0 for
+// a straight finger and grows as the finger curls, to about 180 for a fist,
+// which suits driving a rigged hand more smoothly than Extended. It's NaN if
+// a joint position isn't set.
+func (p *Pointable) ExtensionAngle() float64 {
+	joints := [][]float64{p.McpPosition, p.PipPosition, p.DipPosition, p.TipPosition}
+	for _, j := range joints {
+		if !isVector(j) {
+			return math.NaN()
+		}
+	}
+
+	angle := 0.0
+	for k := 1; k < len(joints)-1; k++ {
+		angle += bendAngle(joints[k-1], joints[k], joints[k+1])
+	}
+	return angle
+}
+
+// bendAngle returns the angle in degrees between the bone from a to b and the
+// bone from b to c, 0 if they are aligned or either has no length
+func bendAngle(a, b, c []float64) float64 {
+	u := normalize([]float64{b[0] - a[0], b[1] - a[1], b[2] - a[2]})
+	v := normalize([]float64{c[0] - b[0], c[1] - b[1], c[2] - b[2]})
+	if u == nil || v == nil {
+		return 0
+	}
+
+	cos := u[0]*v[0] + u[1]*v[1] + u[2]*v[2]
+	return math.Acos(math.Max(-1, math.Min(1, cos))) * 180 / math.Pi
+}
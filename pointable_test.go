@@ -0,0 +1,67 @@
+package leapmotion
+
+import (
+	"encoding/json"
+	"math"
+	"testing"
+)
+
+func TestPointableUnmarshalJSON(t *testing.T) {
+	tests := []struct {
+		raw  string
+		typ  FingerType
+		tool bool
+	}{
+		{`{"id": 1, "type": 2}`, FingerMiddle, false},
+		{`{"id": 1, "type": "3"}`, FingerRing, false},
+		{`{"id": 1, "type": "finger"}`, FingerUnknown, false},
+		{`{"id": 1, "type": "tool", "tool": true}`, FingerUnknown, true},
+		{`{"id": 1, "type": null}`, FingerThumb, false},
+		{`{"id": 1}`, FingerThumb, false},
+	}
+
+	for _, test := range tests {
+		var p Pointable
+		if err := json.Unmarshal([]byte(test.raw), &p); err != nil {
+			t.Fatal(err)
+		}
+		if p.ID != 1 || p.Type != test.typ || p.Tool != test.tool {
+			t.Fatalf("Received %+v for %s. Expected type %d, tool %t", p, test.raw, test.typ, test.tool)
+		}
+	}
+
+	// A frame with string types keeps its pointables
+	var frame *Frame
+	c := newClient(func(f *Frame) { frame = f }, nil)
+	c.handleMessage([]byte(`{"id": 1, "pointables": [{"id": 3, "type": "finger"}, {"id": 4, "type": "tool"}]}`))
+	if frame == nil || len(frame.Pointables) != 2 {
+		t.Fatalf("Received %v. Expected a frame with 2 pointables", frame)
+	}
+}
+
+func TestExtensionAngle(t *testing.T) {
+	straight := Pointable{
+		McpPosition: []float64{0, 0, 0},
+		PipPosition: []float64{0, 0, -40},
+		DipPosition: []float64{0, 0, -65},
+		TipPosition: []float64{0, 0, -85},
+	}
+	if a := straight.ExtensionAngle(); math.Abs(a) > 1e-9 {
+		t.Fatalf("Received %f. Expected 0 for a straight finger", a)
+	}
+
+	// Bent 90 degrees at the PIP joint and 45 more at the DIP joint
+	bent := Pointable{
+		McpPosition: []float64{0, 0, 0},
+		PipPosition: []float64{0, 0, -40},
+		DipPosition: []float64{0, -25, -40},
+		TipPosition: []float64{0, -35, -30},
+	}
+	if a := bent.ExtensionAngle(); math.Abs(a-135) > 1e-9 {
+		t.Fatalf("Received %f. Expected 135", a)
+	}
+
+	if a := (&Pointable{}).ExtensionAngle(); !math.IsNaN(a) {
+		t.Fatalf("Received %f. Expected NaN without joint positions", a)
+	}
+}
@@ -0,0 +1,153 @@
+package leapmotion
+
+import (
+	"math"
+	"time"
+)
+
+// Defaults of a PointingPose
+const (
+	// DefaultPointingSpeed is the index fingertip speed, in millimeters per
+	// second, above which a pointing finger isn't held steady
+	DefaultPointingSpeed = 100
+	// DefaultPointingAngle is the largest angle, in degrees, between the
+	// index finger and -Z, away from the user, that counts as pointing forward
+	DefaultPointingAngle = 45
+)
+
+// PointingPose recognizes the pose of a laser pointer: the index finger
+// extended, the middle, ring and pinky fingers curled, the index fingertip
+// moving slower than MaxSpeed and its direction within MaxAngle of -Z. The
+// thumb is ignored. Once the pose has been held for Hold, OnPointing fires
+// for every frame the pose is held in; OnPointingEnd fires once when it's
+// broken or the hand leaves the frame. Feed it every frame with Update.
+type PointingPose struct {
+	// Hold is how long the pose must be held before OnPointing fires
+	Hold time.Duration
+	// MaxSpeed is the index fingertip speed above which the pose is broken
+	MaxSpeed float64
+	// MaxAngle is the largest angle, in degrees, between the index finger
+	// and -Z
+	MaxAngle float64
+
+	detector[*pointingState]
+	onPointing func(handID int, index *Pointable)
+	onEnd      func(handID int)
+}
+
+type pointingState struct {
+	since    int // frame timestamp (microseconds) the pose started at
+	pointing bool
+}
+
+// NewPointingPose returns a PointingPose firing once the pose is held for
+// hold, with the default speed and angle thresholds
+func NewPointingPose(hold time.Duration) *PointingPose {
+	return &PointingPose{
+		Hold:     hold,
+		MaxSpeed: DefaultPointingSpeed,
+		MaxAngle: DefaultPointingAngle,
+	}
+}
+
+// OnPointing registers cb to be called, for every frame the pose is held in,
+// with the ID of the hand and its index finger
+func (d *PointingPose) OnPointing(cb func(handID int, index *Pointable)) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	d.onPointing = cb
+}
+
+// OnPointingEnd registers cb to be called with the ID of a hand that stopped
+// pointing
+func (d *PointingPose) OnPointingEnd(cb func(handID int)) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	d.onEnd = cb
+}
+
+// Update feeds frame to the detector, calling the OnPointing and
+// OnPointingEnd callbacks. The state of hands that left the frame is reset.
+func (d *PointingPose) Update(frame *Frame) {
+	type pointing struct {
+		handID int
+		index  *Pointable
+	}
+	var held []pointing
+	var ended []int
+
+	d.mu.Lock()
+	if !d.next(frame) {
+		d.mu.Unlock()
+		return
+	}
+
+	hold := int(d.Hold / time.Microsecond)
+	for i := range frame.Hands {
+		hand := &frame.Hands[i]
+
+		index := d.pose(frame, hand)
+		if index == nil {
+			if st, ok := d.hands[hand.ID]; ok && st.pointing {
+				ended = append(ended, hand.ID)
+			}
+			delete(d.hands, hand.ID)
+			continue
+		}
+		st := d.hands.get(hand.ID, func() *pointingState { return &pointingState{since: frame.Timestamp} })
+
+		if frame.Timestamp-st.since >= hold {
+			st.pointing = true
+			held = append(held, pointing{hand.ID, index})
+		}
+	}
+
+	d.hands.forgetAbsent(frame, func(id int, st *pointingState) {
+		if st.pointing {
+			ended = append(ended, id)
+		}
+	})
+	onPointing, onEnd := d.onPointing, d.onEnd
+	d.mu.Unlock()
+
+	if onEnd != nil {
+		for _, id := range ended {
+			onEnd(id)
+		}
+	}
+	if onPointing != nil {
+		for _, p := range held {
+			onPointing(p.handID, p.index)
+		}
+	}
+}
+
+// pose returns the index finger of hand if the hand is in the pointing pose in
+// frame, or nil
+func (d *PointingPose) pose(frame *Frame, hand *Hand) *Pointable {
+	index := hand.Finger(frame, FingerIndex)
+	if index == nil || !index.Extended {
+		return nil
+	}
+	for _, t := range []FingerType{FingerMiddle, FingerRing, FingerPinky} {
+		if f := hand.Finger(frame, t); f != nil && f.Extended {
+			return nil
+		}
+	}
+
+	if !isVector(index.TipVelocity) || distanceSquared(index.TipVelocity, []float64{0, 0, 0}) > d.MaxSpeed*d.MaxSpeed {
+		return nil
+	}
+
+	if !isVector(index.Direction) {
+		return nil
+	}
+	u := normalize(index.Direction)
+	if u == nil || -u[2] < math.Cos(d.MaxAngle*math.Pi/180) {
+		return nil
+	}
+
+	return index
+}
@@ -0,0 +1,77 @@
+package leapmotion
+
+import (
+	"testing"
+	"time"
+)
+
+func TestPointingPose(t *testing.T) {
+	d := NewPointingPose(200 * time.Millisecond)
+
+	pointing, ended := 0, 0
+	d.OnPointing(func(handID int, index *Pointable) {
+		if handID != 1 || index.Type != FingerIndex {
+			t.Fatalf("Received hand %d finger %d. Expected the index finger of hand 1", handID, index.Type)
+		}
+		pointing++
+	})
+	d.OnPointingEnd(func(handID int) { ended++ })
+
+	id := 0
+	frame := func(middleExtended bool, speed float64, direction []float64) *Frame {
+		id++
+		f := testFrame(id, id*100000, Hand{ID: 1})
+		f.Pointables = []Pointable{
+			{HandID: 1, Type: FingerThumb, Extended: true},
+			{HandID: 1, Type: FingerIndex, Extended: true, TipVelocity: []float64{0, speed, 0}, Direction: direction},
+			{HandID: 1, Type: FingerMiddle, Extended: middleExtended},
+			{HandID: 1, Type: FingerRing},
+			{HandID: 1, Type: FingerPinky},
+		}
+		return f
+	}
+	forward := []float64{0, 0.2, -1}
+
+	d.Update(frame(false, 10, forward))
+	d.Update(frame(false, 10, forward))
+	if pointing != 0 {
+		t.Fatalf("Received %d pointing frames. Expected none before the hold", pointing)
+	}
+	d.Update(frame(false, 10, forward))
+	d.Update(frame(false, 10, forward))
+	if pointing != 2 || ended != 0 {
+		t.Fatalf("Received %d pointing frames and %d ends. Expected 2 and 0", pointing, ended)
+	}
+
+	// Each condition breaks the pose
+	for _, broken := range []struct {
+		middleExtended bool
+		speed          float64
+		direction      []float64
+	}{
+		{true, 10, forward},
+		{false, 300, forward},
+		{false, 10, []float64{0, -1, 0}},
+	} {
+		pointing, ended = 0, 0
+		d.Update(frame(broken.middleExtended, broken.speed, broken.direction))
+		if pointing != 0 || ended != 1 {
+			t.Fatalf("Received %d pointing frames and %d ends for %v. Expected 0 and 1", pointing, ended, broken)
+		}
+		for i := 0; i < 3; i++ {
+			d.Update(frame(false, 10, forward))
+		}
+	}
+
+	// The hand leaves the frame
+	pointing, ended = 0, 0
+	id++
+	d.Update(testFrame(id, id*100000))
+	if ended != 1 {
+		t.Fatalf("Received %d ends. Expected 1 when the hand leaves", ended)
+	}
+	d.Update(frame(false, 10, forward))
+	if pointing != 0 {
+		t.Fatal("Expected the hold to restart after the hand came back")
+	}
+}
@@ -0,0 +1,312 @@
+package leapmotion
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"sync"
+	"time"
+)
+
+// DefaultMaxReceiveErrors is the number of consecutive failed reads after
+// which a connection is considered broken
+const DefaultMaxReceiveErrors = 10
+
+// processing is one run of the message loop, from Connect or StartProcessing
+// until StopProcessing
+type processing struct {
+	stop chan struct{}
+	wg   sync.WaitGroup
+}
+
+// startProcessing starts the goroutines reading and dispatching messages.
+// c.procMu must be held, or the client not yet shared.
+func (c *Client) startProcessing() {
+	p := &processing{stop: make(chan struct{})}
+	msgs := c.startReceiving(p)
+
+	p.wg.Add(1)
+	go func() {
+		defer p.wg.Done()
+		c.processData(p, msgs)
+	}()
+
+	c.proc = p
+}
+
+// startReceiving starts the goroutine reading messages from the current
+// connection for p
+func (c *Client) startReceiving(p *processing) <-chan received {
+	msgs := make(chan received, messagesBuffer)
+	conn := c.transport()
+
+	p.wg.Add(1)
+	go func() {
+		defer p.wg.Done()
+		c.receive(conn, p.stop, msgs)
+	}()
+
+	return msgs
+}
+
+// finish closes Done and Errors once the connection has ended
+func (c *Client) finish() {
+	c.finishOnce.Do(func() {
+		c.mu.Lock()
+		c.finished = true
+		close(c.errs)
+		c.mu.Unlock()
+
+		close(c.done)
+	})
+}
+
+// processData dispatches the messages read by receive until the connection
+// ends, and can't be reconnected, the client is closed or p is stopped
+func (c *Client) processData(p *processing, msgs <-chan received) {
+	// Without WithIdleFrames idle stays nil and never fires
+	var idle <-chan time.Time
+	var timer *time.Timer
+	if c.opts.idle > 0 {
+		timer = time.NewTimer(c.opts.idle)
+		defer timer.Stop()
+		idle = timer.C
+	}
+
+	for {
+		select {
+		case r := <-msgs:
+			if c.takeDrain() {
+				r = c.drainToLatest(msgs, r)
+			}
+			if r.err != nil {
+				if msgs = c.reconnect(p, r.err); msgs != nil {
+					continue
+				}
+				select {
+				case <-p.stop:
+				default:
+					c.finish()
+				}
+				return
+			}
+			if c.handleMessageAt(r.raw, r.at) && timer != nil {
+				if !timer.Stop() {
+					<-timer.C
+				}
+				timer.Reset(c.opts.idle)
+			}
+		case <-idle:
+			c.handleFrame(&Frame{Synthetic: true})
+			timer.Reset(c.opts.idle)
+		case <-p.stop:
+			return
+		case <-c.closing:
+			return
+		}
+	}
+}
+
+// takeDrain reports whether a drain to the latest message is due after Resume
+func (c *Client) takeDrain() bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	draining := c.draining
+	c.draining = false
+	return draining
+}
+
+// drainToLatest reads the messages queued after r without blocking and
+// returns the most recent one. The messages skipped are read without handing
+// their frames to the frameHandler.
+func (c *Client) drainToLatest(msgs <-chan received, r received) received {
+	for r.err == nil {
+		select {
+		case next := <-msgs:
+			c.readMessage(r.raw, r.at)
+			r = next
+		default:
+			return r
+		}
+	}
+	return r
+}
+
+// reconnect redials the service after the connection ended with err, as
+// configured with WithReconnect, and starts receiving from the new connection.
+// It returns nil if the client should end instead: reconnecting is off, the
+// retries are used up, an error isn't worth retrying, or the client has been
+// closed or p stopped meanwhile.
+func (c *Client) reconnect(p *processing, err error) <-chan received {
+	c.setReconnecting(true)
+	defer c.setReconnecting(false)
+
+	delay := c.opts.reconnectDelay
+	for retry := 0; retry < c.opts.reconnectRetries; retry++ {
+		if c.isClosed() || !c.shouldReconnect(err) {
+			return nil
+		}
+		if retry == 0 {
+			c.transport().Close()
+		}
+
+		select {
+		case <-time.After(delay):
+		case <-p.stop:
+			return nil
+		case <-c.closing:
+			return nil
+		}
+		delay *= 2
+
+		if err = c.connect(); err == nil {
+			return c.startReceiving(p)
+		}
+		c.reportError(err)
+	}
+	return nil
+}
+
+func (c *Client) setReconnecting(reconnecting bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.reconnecting = reconnecting
+}
+
+// Reconnecting reports whether the connection ended and the client is
+// redialing the service, as configured with WithReconnect, e.g. to show a
+// reconnecting state. It's false again once a new connection is set up or the
+// retries are used up.
+func (c *Client) Reconnecting() bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.reconnecting
+}
+
+func (c *Client) shouldReconnect(err error) bool {
+	return c.opts.shouldReconnect == nil || c.opts.shouldReconnect(err)
+}
+
+// received is a message read from the socket and the time it was read at, or
+// the error that ended the connection
+type received struct {
+	raw []byte
+	at  time.Time
+	err error
+}
+
+// receive reads messages from conn and queues them for processData until stop
+// is closed. Once the other end has closed the connection, the connection is
+// closed underneath the client, or reading failed too many times in a row, the
+// error is queued and receive returns.
+func (c *Client) receive(conn Transport, stop <-chan struct{}, msgs chan<- received) {
+	failures := 0
+	for {
+		raw, err := conn.Receive()
+
+		select {
+		case <-stop:
+			return
+		case <-c.closing:
+			return
+		default:
+		}
+
+		switch {
+		case err == nil || err == io.EOF:
+		case closedConnection(err):
+			// Retrying a closed connection would only spin
+			c.reportError(err)
+		default:
+			failures++
+			if failures < c.maxReceiveErrors() {
+				continue
+			}
+			err = fmt.Errorf("%w: %d times in a row, last: %v", ErrReceiveFailed, failures, err)
+			c.reportError(err)
+		}
+		if err != nil {
+			select {
+			case msgs <- received{err: err}:
+			case <-stop:
+			case <-c.closing:
+			}
+			return
+		}
+		failures = 0
+
+		select {
+		case msgs <- received{raw: raw, at: time.Now()}:
+		case <-stop:
+			return
+		case <-c.closing:
+			return
+		}
+	}
+}
+
+// closedConnection reports whether err is from reading a connection that has
+// been closed, which no read will succeed on again
+func closedConnection(err error) bool {
+	return errors.Is(err, net.ErrClosed) || errors.Is(err, io.ErrClosedPipe)
+}
+
+func (c *Client) maxReceiveErrors() int {
+	if c.opts.maxReceiveErrors > 0 {
+		return c.opts.maxReceiveErrors
+	}
+	return DefaultMaxReceiveErrors
+}
+
+// readDeadliner is implemented by transports whose Receive can be interrupted
+type readDeadliner interface {
+	SetReadDeadline(t time.Time) error
+}
+
+// StopProcessing stops reading messages from the WebSocket without closing it,
+// e.g. to hand the connection to another subsystem for a while. Done isn't
+// closed. A message being read when processing stops is dropped. If the
+// Transport can't interrupt Receive, StopProcessing waits for the next message.
+func (c *Client) StopProcessing() {
+	c.procMu.Lock()
+	defer c.procMu.Unlock()
+
+	p := c.proc
+	if p == nil {
+		return
+	}
+	c.proc = nil
+	close(p.stop)
+
+	d, ok := c.transport().(readDeadliner)
+	if ok {
+		d.SetReadDeadline(time.Now())
+	}
+	p.wg.Wait()
+	if ok {
+		d.SetReadDeadline(time.Time{})
+	}
+}
+
+// StartProcessing resumes reading messages from the WebSocket after
+// StopProcessing. It does nothing if processing is running or the connection
+// has ended.
+func (c *Client) StartProcessing() {
+	c.procMu.Lock()
+	defer c.procMu.Unlock()
+
+	if c.proc != nil {
+		return
+	}
+	select {
+	case <-c.done:
+		return
+	default:
+	}
+
+	c.startProcessing()
+}
@@ -0,0 +1,70 @@
+package leapmotion
+
+import "math"
+
+// Ray returns a ray starting at the tip of the pointable and running along
+// its direction, for ray based selection. Direction is normalized. Both are
+// nil if the pointable doesn't have a tip position or direction.
+func (p *Pointable) Ray() (origin, direction []float64) {
+	if !isVector(p.TipPosition) || !isVector(p.Direction) {
+		return nil, nil
+	}
+
+	direction = normalize(p.Direction)
+	if direction == nil {
+		return nil, nil
+	}
+
+	return []float64{p.TipPosition[0], p.TipPosition[1], p.TipPosition[2]}, direction
+}
+
+// RayHitsBox intersects a ray with the interaction box. If the ray starts
+// outside the box the point where it enters the box is returned, otherwise
+// the point where it leaves the box is returned. The bool is false if the
+// ray misses the box or the box isn't set.
+func RayHitsBox(origin, direction []float64, box *InteractionBox) ([]float64, bool) {
+	if box == nil || !isVector(origin) || !isVector(direction) {
+		return nil, false
+	}
+	if len(box.Center) < 3 || len(box.Size) < 3 {
+		return nil, false
+	}
+
+	// Slab intersection: clip the ray against each pair of axis aligned planes
+	tNear := math.Inf(-1)
+	tFar := math.Inf(1)
+	for k := 0; k < 3; k++ {
+		min := float64(box.Center[k]) - box.Size[k]/2
+		max := float64(box.Center[k]) + box.Size[k]/2
+
+		if direction[k] == 0 {
+			if origin[k] < min || origin[k] > max {
+				return nil, false
+			}
+			continue
+		}
+
+		t1 := (min - origin[k]) / direction[k]
+		t2 := (max - origin[k]) / direction[k]
+		if t1 > t2 {
+			t1, t2 = t2, t1
+		}
+		tNear = math.Max(tNear, t1)
+		tFar = math.Min(tFar, t2)
+	}
+
+	if tNear > tFar || tFar < 0 {
+		return nil, false
+	}
+
+	t := tNear
+	if t < 0 {
+		t = tFar
+	}
+
+	return []float64{
+		origin[0] + direction[0]*t,
+		origin[1] + direction[1]*t,
+		origin[2] + direction[2]*t,
+	}, true
+}
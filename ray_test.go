@@ -0,0 +1,55 @@
+package leapmotion
+
+import "testing"
+
+func TestRayHitsBox(t *testing.T) {
+	box := &InteractionBox{
+		Center: []int{0, 200, 0},
+		Size:   []float64{200, 200, 200},
+	}
+
+	tests := []struct {
+		origin    []float64
+		direction []float64
+		hit       bool
+		expected  []float64
+	}{
+		// From inside the box the ray leaves through the far face
+		{[]float64{0, 200, 0}, []float64{0, 0, -1}, true, []float64{0, 200, -100}},
+		// From outside the box the ray enters through the near face
+		{[]float64{0, 200, 300}, []float64{0, 0, -1}, true, []float64{0, 200, 100}},
+		// Pointing away from the box
+		{[]float64{0, 200, 300}, []float64{0, 0, 1}, false, nil},
+		// Parallel to the box but outside it
+		{[]float64{0, 400, 300}, []float64{0, 0, -1}, false, nil},
+	}
+
+	for _, test := range tests {
+		point, hit := RayHitsBox(test.origin, test.direction, box)
+		if hit != test.hit {
+			t.Fatalf("Received hit %t. Expected %t", hit, test.hit)
+		}
+
+		for i, p := range point {
+			if p != test.expected[i] {
+				t.Fatalf("Received %f. Expected %f", point, test.expected)
+			}
+		}
+	}
+}
+
+func TestPointableRay(t *testing.T) {
+	p := Pointable{TipPosition: []float64{1, 2, 3}, Direction: []float64{0, 0, -5}}
+
+	origin, direction := p.Ray()
+	if origin[0] != 1 || origin[1] != 2 || origin[2] != 3 {
+		t.Fatalf("Received origin %f. Expected %f", origin, p.TipPosition)
+	}
+	if direction[0] != 0 || direction[1] != 0 || direction[2] != -1 {
+		t.Fatalf("Received direction %f. Expected [0 0 -1]", direction)
+	}
+
+	if origin, direction := (&Pointable{}).Ray(); origin != nil || direction != nil {
+		t.Fatal("Expected a nil ray for a pointable without a tip position")
+	}
+}
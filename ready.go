@@ -0,0 +1,116 @@
+package leapmotion
+
+import "time"
+
+// Defaults of a ReadyDetector
+const (
+	// DefaultReadySpeed is the palm speed, in millimeters per second, above
+	// which a hand isn't held steady
+	DefaultReadySpeed = 50
+	// DefaultReadyConfidence is the minimum tracking confidence of a ready hand
+	DefaultReadyConfidence = 0.5
+	// DefaultReadyVisible is how long, in seconds, a hand must have been
+	// tracked before it can be ready
+	DefaultReadyVisible = 0.5
+)
+
+// ReadyDetector recognizes a user presenting a hand and holding it steady, the
+// signal onboarding flows wait for before starting. A hand is ready once it
+// has been tracked for MinVisible seconds with at least MinConfidence and its
+// palm has moved slower than MaxSpeed for Hold. Any frame failing one of the
+// conditions restarts the hold. OnReady fires once per hand; it fires again
+// only after the hand has left the frame and come back. Feed it every frame
+// with Update.
+type ReadyDetector struct {
+	// Hold is how long the hand must be held steady
+	Hold time.Duration
+	// MaxSpeed is the palm speed above which the hand isn't steady
+	MaxSpeed float64
+	// MinConfidence is the minimum Hand.Confidence
+	MinConfidence float64
+	// MinVisible is the minimum Hand.TimeVisible, in seconds
+	MinVisible float64
+
+	detector[*readyState]
+	onReady func(handID int)
+}
+
+type readyState struct {
+	steady      bool
+	steadySince int // frame timestamp (microseconds) the hold started at
+	fired       bool
+}
+
+// NewReadyDetector returns a ReadyDetector firing for a hand held steady for
+// hold, with the default speed, confidence and visibility thresholds
+func NewReadyDetector(hold time.Duration) *ReadyDetector {
+	return &ReadyDetector{
+		Hold:          hold,
+		MaxSpeed:      DefaultReadySpeed,
+		MinConfidence: DefaultReadyConfidence,
+		MinVisible:    DefaultReadyVisible,
+	}
+}
+
+// OnReady registers cb to be called with the ID of a hand that is ready
+func (d *ReadyDetector) OnReady(cb func(handID int)) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	d.onReady = cb
+}
+
+// Update feeds frame to the detector, calling the OnReady callback for every
+// hand that became ready. The state of hands that left the frame is reset.
+func (d *ReadyDetector) Update(frame *Frame) {
+	var ready []int
+
+	d.mu.Lock()
+	if !d.next(frame) {
+		d.mu.Unlock()
+		return
+	}
+
+	hold := int(d.Hold / time.Microsecond)
+	for i := range frame.Hands {
+		hand := &frame.Hands[i]
+		st := d.hands.get(hand.ID, func() *readyState { return &readyState{} })
+
+		if !d.steady(hand) {
+			st.steady = false
+			continue
+		}
+		if !st.steady {
+			st.steady = true
+			st.steadySince = frame.Timestamp
+		}
+
+		if !st.fired && frame.Timestamp-st.steadySince >= hold {
+			st.fired = true
+			ready = append(ready, hand.ID)
+		}
+	}
+
+	d.hands.forgetAbsent(frame, nil)
+	cb := d.onReady
+	d.mu.Unlock()
+
+	if cb != nil {
+		for _, id := range ready {
+			cb(id)
+		}
+	}
+}
+
+// steady reports whether hand meets the conditions of a ready hand in a
+// single frame
+func (d *ReadyDetector) steady(hand *Hand) bool {
+	if hand.Confidence < d.MinConfidence || hand.TimeVisible < d.MinVisible {
+		return false
+	}
+	if !isVector(hand.PalmVelocity) {
+		return false
+	}
+
+	return distanceSquared(hand.PalmVelocity, []float64{0, 0, 0}) <= d.MaxSpeed*d.MaxSpeed
+}
@@ -0,0 +1,49 @@
+package leapmotion
+
+import (
+	"testing"
+	"time"
+)
+
+func TestReadyDetector(t *testing.T) {
+	d := NewReadyDetector(300 * time.Millisecond)
+
+	var ready []int
+	d.OnReady(func(handID int) { ready = append(ready, handID) })
+
+	frame := func(id int, speed, confidence float64) *Frame {
+		return testFrame(id, id*100000, Hand{
+			ID:           1,
+			Confidence:   confidence,
+			TimeVisible:  1,
+			PalmVelocity: []float64{speed, 0, 0},
+		})
+	}
+
+	d.Update(frame(1, 10, 0.9))
+	d.Update(frame(2, 10, 0.9))
+	d.Update(frame(3, 200, 0.9)) // moved, the hold restarts
+	d.Update(frame(4, 10, 0.9))
+	d.Update(frame(5, 10, 0.2)) // low confidence, the hold restarts
+	d.Update(frame(6, 10, 0.9))
+	d.Update(frame(7, 10, 0.9))
+	d.Update(frame(8, 10, 0.9))
+	if len(ready) != 0 {
+		t.Fatalf("Received %v. Expected no ready hand before the hold", ready)
+	}
+
+	d.Update(frame(9, 10, 0.9))
+	d.Update(frame(10, 10, 0.9))
+	if len(ready) != 1 || ready[0] != 1 {
+		t.Fatalf("Received %v. Expected hand 1 ready once", ready)
+	}
+
+	// The hand leaves and comes back
+	d.Update(testFrame(11, 1100000))
+	for id := 12; id <= 16; id++ {
+		d.Update(frame(id, 10, 0.9))
+	}
+	if len(ready) != 2 {
+		t.Fatalf("Received %v. Expected hand 1 ready again after coming back", ready)
+	}
+}
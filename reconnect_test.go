@@ -0,0 +1,216 @@
+package leapmotion
+
+import (
+	"errors"
+	"io"
+	"sync"
+	"testing"
+	"time"
+)
+
+// dialSequence returns a DialFunc handing out transports in turn, failing
+// with err once they are used up
+func dialSequence(err error, transports ...*fakeTransport) (DialFunc, func() int) {
+	var mu sync.Mutex
+	dials := 0
+
+	dial := func(string) (Transport, error) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		dials++
+		if dials > len(transports) {
+			return nil, err
+		}
+		return transports[dials-1], nil
+	}
+	count := func() int {
+		mu.Lock()
+		defer mu.Unlock()
+
+		return dials
+	}
+	return dial, count
+}
+
+func TestReconnect(t *testing.T) {
+	first := newFakeTransport(`{"id": 1}`)
+	second := newFakeTransport(`{"id": 2}`)
+	dial, _ := dialSequence(errors.New("refused"), first, second)
+
+	frames := make(chan *Frame, 10)
+	c, err := Connect(func(frame *Frame) {
+		frames <- frame
+	}, WithDialer(dial), WithReconnect(3, time.Millisecond))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer c.Close()
+
+	for _, id := range []float64{1, 2} {
+		select {
+		case frame := <-frames:
+			if frame.ID != id {
+				t.Fatalf("Received %v. Expected frame %v", frame.ID, id)
+			}
+		case <-time.After(time.Second):
+			t.Fatalf("Timed out waiting for frame %v", id)
+		}
+
+		if id == 1 {
+			first.errs <- io.EOF
+		}
+	}
+
+	if sent := second.sentMessages(); len(sent) != 2 {
+		t.Fatalf("Received %d setup messages. Expected 2 on the new connection", len(sent))
+	}
+}
+
+func TestReconnectRunsOnConnect(t *testing.T) {
+	first := newFakeTransport()
+	second := newFakeTransport()
+	dial, _ := dialSequence(errors.New("refused"), first, second)
+
+	connected := make(chan Transport, 2)
+	c, err := Connect(nil, WithDialer(dial), WithReconnect(3, time.Millisecond), WithOnConnect(func(c *Client) error {
+		c.mu.Lock()
+		connected <- c.conn
+		c.mu.Unlock()
+		return nil
+	}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer c.Close()
+
+	first.errs <- io.EOF
+
+	for _, expected := range []*fakeTransport{first, second} {
+		select {
+		case conn := <-connected:
+			if conn != expected {
+				t.Fatal("Expected the callback to run on each connection in turn")
+			}
+		case <-time.After(time.Second):
+			t.Fatal("Timed out waiting for the callback")
+		}
+	}
+}
+
+func TestShouldReconnect(t *testing.T) {
+	transport := newFakeTransport()
+	rejected := errors.New("unauthorized")
+	dial, dials := dialSequence(rejected, transport)
+
+	c, err := Connect(nil, WithDialer(dial), WithReconnect(5, time.Millisecond),
+		WithShouldReconnect(func(err error) bool {
+			return err == io.EOF
+		}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer c.Close()
+
+	transport.errs <- io.EOF
+
+	select {
+	case <-c.Done():
+	case <-time.After(time.Second):
+		t.Fatal("Timed out waiting for Done")
+	}
+
+	if n := dials(); n != 2 {
+		t.Fatalf("Received %d dials. Expected the rejected dial not to be retried", n)
+	}
+}
+
+func TestReconnectReplaysConfig(t *testing.T) {
+	first := newFakeTransport(`{"version": 6}`)
+	second := newFakeTransport()
+	dial, _ := dialSequence(errors.New("refused"), first, second)
+
+	c, err := Connect(nil, WithDialer(dial), WithReconnect(3, time.Millisecond))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer c.Close()
+
+	deadline := time.Now().Add(time.Second)
+	for c.Handshake().Version != 6 {
+		if time.Now().After(deadline) {
+			t.Fatal("Timed out waiting for the handshake")
+		}
+		time.Sleep(time.Millisecond)
+	}
+
+	for _, set := range []func() error{
+		func() error { return c.SetGestureParam("Gesture.Swipe.MinLength", 200) },
+		func() error { return c.SetGestureParam("Gesture.Swipe.MinLength", 150) },
+	} {
+		if err := set(); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	first.errs <- io.EOF
+
+	sent := waitSent(t, second, 3)
+	expected := []string{`{"enableGestures":true}`, `{"backgroundMessage":true}`, `{"Gesture.Swipe.MinLength":150}`}
+	if len(sent) != len(expected) {
+		t.Fatalf("Received %v. Expected %v", sent, expected)
+	}
+	for i := range expected {
+		if sent[i] != expected[i] {
+			t.Fatalf("Received %v. Expected %v", sent, expected)
+		}
+	}
+}
+
+func TestReconnecting(t *testing.T) {
+	first := newFakeTransport()
+	second := newFakeTransport(`{"id": 2}`)
+
+	release := make(chan struct{})
+	dials := 0
+	dial := func(string) (Transport, error) {
+		dials++
+		if dials == 1 {
+			return first, nil
+		}
+		<-release
+		return second, nil
+	}
+
+	frames := make(chan *Frame, 10)
+	c, err := Connect(func(frame *Frame) {
+		frames <- frame
+	}, WithDialer(dial), WithReconnect(3, time.Millisecond))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer c.Close()
+
+	if c.Reconnecting() {
+		t.Fatal("Expected not to be reconnecting while connected")
+	}
+
+	first.errs <- io.EOF
+	deadline := time.Now().Add(time.Second)
+	for !c.Reconnecting() {
+		if time.Now().After(deadline) {
+			t.Fatal("Timed out waiting for the reconnect to start")
+		}
+		time.Sleep(time.Millisecond)
+	}
+
+	close(release)
+	select {
+	case <-frames:
+	case <-time.After(time.Second):
+		t.Fatal("Timed out waiting for a frame from the new connection")
+	}
+	if c.Reconnecting() {
+		t.Fatal("Expected not to be reconnecting once reconnected")
+	}
+}
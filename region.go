@@ -0,0 +1,114 @@
+package leapmotion
+
+// Region is a rectangle of the normalized X-Y plane of InteractionBox.
+// NormalizePoint, where X grows to the right and Y upward from 0 to 1 across
+// the interaction box, e.g. a button of an on screen menu
+type Region struct {
+	MinX, MinY float64
+	MaxX, MaxY float64
+}
+
+// Contains reports whether the normalized point lies within the region,
+// edges included. Z is ignored.
+func (r Region) Contains(normalized []float64) bool {
+	if len(normalized) < 2 {
+		return false
+	}
+
+	x, y := normalized[0], normalized[1]
+	return x >= r.MinX && x <= r.MaxX && y >= r.MinY && y <= r.MaxY
+}
+
+// RegionWatcher fires enter and leave events as the index fingertip of each
+// hand, normalized with the interaction box of the frame, crosses Region. The
+// stabilized tip position is used when the frame has it. Feed it every frame
+// with Update.
+type RegionWatcher struct {
+	Region Region
+
+	detector[bool] // whether the fingertip of each hand is inside
+	onEnter        func(handID int)
+	onLeave        func(handID int)
+}
+
+// NewRegionWatcher returns a RegionWatcher for r
+func NewRegionWatcher(r Region) *RegionWatcher {
+	return &RegionWatcher{Region: r}
+}
+
+// OnEnter registers cb to be called with the ID of a hand whose fingertip
+// entered the region
+func (w *RegionWatcher) OnEnter(cb func(handID int)) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	w.onEnter = cb
+}
+
+// OnLeave registers cb to be called with the ID of a hand whose fingertip left
+// the region, including by the hand or finger no longer being tracked
+func (w *RegionWatcher) OnLeave(cb func(handID int)) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	w.onLeave = cb
+}
+
+// Update feeds frame to the watcher, calling the OnEnter and OnLeave callbacks
+// for every hand that crossed the region
+func (w *RegionWatcher) Update(frame *Frame) {
+	var entered, left []int
+
+	w.mu.Lock()
+	if !w.next(frame) {
+		w.mu.Unlock()
+		return
+	}
+
+	for i := range frame.Hands {
+		hand := &frame.Hands[i]
+
+		inside := w.contains(frame, hand)
+		if inside && !w.hands[hand.ID] {
+			entered = append(entered, hand.ID)
+		} else if !inside && w.hands[hand.ID] {
+			left = append(left, hand.ID)
+		}
+		w.hands.set(hand.ID, inside)
+	}
+
+	w.hands.forgetAbsent(frame, func(id int, inside bool) {
+		if inside {
+			left = append(left, id)
+		}
+	})
+	onEnter, onLeave := w.onEnter, w.onLeave
+	w.mu.Unlock()
+
+	if onLeave != nil {
+		for _, id := range left {
+			onLeave(id)
+		}
+	}
+	if onEnter != nil {
+		for _, id := range entered {
+			onEnter(id)
+		}
+	}
+}
+
+// contains reports whether the index fingertip of hand is in the region
+func (w *RegionWatcher) contains(frame *Frame, hand *Hand) bool {
+	finger := hand.Finger(frame, FingerIndex)
+	if finger == nil {
+		return false
+	}
+
+	tip := finger.StabilizedTipPosition
+	if !isVector(tip) {
+		tip = finger.TipPosition
+	}
+
+	normalized, err := frame.InteractionBox.NormalizePoint(tip, false)
+	return err == nil && w.Region.Contains(normalized)
+}
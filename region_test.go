@@ -0,0 +1,61 @@
+package leapmotion
+
+import "testing"
+
+func TestRegionContains(t *testing.T) {
+	r := Region{MinX: 0.25, MinY: 0.25, MaxX: 0.5, MaxY: 0.75}
+
+	tests := []struct {
+		point    []float64
+		expected bool
+	}{
+		{[]float64{0.3, 0.5, 0}, true},
+		{[]float64{0.25, 0.75, 1}, true},
+		{[]float64{0.6, 0.5, 0}, false},
+		{[]float64{0.3, 0.1, 0}, false},
+		{nil, false},
+	}
+
+	for _, test := range tests {
+		if inside := r.Contains(test.point); inside != test.expected {
+			t.Fatalf("Received %t for %v. Expected %t", inside, test.point, test.expected)
+		}
+	}
+}
+
+func TestRegionWatcher(t *testing.T) {
+	w := NewRegionWatcher(Region{MinX: 0.5, MinY: 0, MaxX: 1, MaxY: 1})
+
+	var events []string
+	w.OnEnter(func(handID int) { events = append(events, "enter") })
+	w.OnLeave(func(handID int) { events = append(events, "leave") })
+
+	box := InteractionBox{Center: []int{0, 200, 0}, Size: []float64{200, 200, 200}}
+	frame := func(id int, x float64, hand bool) *Frame {
+		f := testFrame(id, id*10000)
+		f.InteractionBox = box
+		if hand {
+			f.Hands = []Hand{{ID: 1}}
+			f.Pointables = []Pointable{{HandID: 1, Type: FingerIndex, TipPosition: []float64{x, 200, 0}}}
+		}
+		return f
+	}
+
+	w.Update(frame(1, -50, true)) // left half, outside
+	w.Update(frame(2, 50, true))  // right half, inside
+	w.Update(frame(2, 50, true))  // repeated frame
+	w.Update(frame(3, 60, true))
+	w.Update(frame(4, -50, true))
+	w.Update(frame(5, 50, true))
+	w.Update(frame(6, 0, false)) // the hand is gone
+
+	expected := []string{"enter", "leave", "enter", "leave"}
+	if len(events) != len(expected) {
+		t.Fatalf("Received %v. Expected %v", events, expected)
+	}
+	for i := range expected {
+		if events[i] != expected[i] {
+			t.Fatalf("Received %v. Expected %v", events, expected)
+		}
+	}
+}
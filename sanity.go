@@ -0,0 +1,59 @@
+package leapmotion
+
+import "math"
+
+// DefaultSanityLimit is the distance in millimeters from the origin beyond
+// which WithSanityCheck considers a position impossible. The tracking range
+// of the sensor ends well within it.
+const DefaultSanityLimit = 1000
+
+// saneFrame reports whether no vector of frame has a NaN or infinite
+// component and no position is further than limit from the origin
+func saneFrame(frame *Frame, limit float64) bool {
+	limitSquared := limit * limit
+
+	finite := func(vectors ...[]float64) bool {
+		for _, v := range vectors {
+			for _, x := range v {
+				if math.IsNaN(x) || math.IsInf(x, 0) {
+					return false
+				}
+			}
+		}
+		return true
+	}
+	plausible := func(positions ...[]float64) bool {
+		for _, p := range positions {
+			if !finite(p) || (isVector(p) && distanceSquared(p, []float64{0, 0, 0}) > limitSquared) {
+				return false
+			}
+		}
+		return true
+	}
+
+	for i := range frame.Hands {
+		h := &frame.Hands[i]
+		if !plausible(h.PalmPosition, h.StabilizedPalmPosition, h.SphereCenter, h.Wrist, h.Elbow) ||
+			!finite(h.PalmVelocity, h.PalmNormal, h.Direction) {
+			return false
+		}
+	}
+
+	for i := range frame.Pointables {
+		p := &frame.Pointables[i]
+		if !plausible(p.TipPosition, p.StabilizedTipPosition, p.BtipPosition, p.DipPosition,
+			p.PipPosition, p.McpPosition, p.CarpPosition) ||
+			!finite(p.TipVelocity, p.Direction) {
+			return false
+		}
+	}
+
+	for i := range frame.Gestures {
+		g := &frame.Gestures[i]
+		if !plausible(g.Position, g.StartPosition, g.Center) || !finite(g.Direction, g.Normal) {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,50 @@
+package leapmotion
+
+import (
+	"math"
+	"testing"
+)
+
+func TestSanityCheck(t *testing.T) {
+	var ids []float64
+	c := newClient(func(frame *Frame) { ids = append(ids, frame.ID) }, []Option{WithSanityCheck(true)})
+
+	for _, raw := range []string{
+		`{"id": 1, "hands": [{"id": 1, "palmPosition": [0, 200, 0]}]}`,
+		`{"id": 2, "hands": [{"id": 1, "palmPosition": [0, 1e30, 0]}]}`,
+		`{"id": 3, "pointables": [{"id": 1, "tipPosition": [0, 2000, 0]}]}`,
+		`{"id": 4, "hands": [{"id": 1, "palmPosition": [0, 200, 0], "palmVelocity": [0, 1500, 0]}]}`,
+	} {
+		c.handleMessage([]byte(raw))
+	}
+
+	if len(ids) != 2 || ids[0] != 1 || ids[1] != 4 {
+		t.Fatalf("Received frames %v. Expected [1 4]", ids)
+	}
+	if stats := c.Stats(); stats.Frames != 4 || stats.Rejected != 2 {
+		t.Fatalf("Received %+v. Expected 4 frames and 2 rejected", stats)
+	}
+
+	c = newClient(nil, []Option{WithSanityLimit(5000)})
+	if limit := c.Config().SanityLimit; limit != 5000 {
+		t.Fatalf("Received %f. Expected 5000", limit)
+	}
+}
+
+func TestSaneFrame(t *testing.T) {
+	tests := []struct {
+		frame    Frame
+		expected bool
+	}{
+		{Frame{Hands: []Hand{{PalmPosition: []float64{0, 200, 0}}}}, true},
+		{Frame{Hands: []Hand{{PalmNormal: []float64{0, math.NaN(), 0}}}}, false},
+		{Frame{Pointables: []Pointable{{TipVelocity: []float64{math.Inf(1), 0, 0}}}}, false},
+		{Frame{Gestures: []Gesture{{Position: []float64{0, 1001, 0}}}}, false},
+	}
+
+	for i, test := range tests {
+		if sane := saneFrame(&test.frame, DefaultSanityLimit); sane != test.expected {
+			t.Fatalf("Test %d: received %t. Expected %t", i, sane, test.expected)
+		}
+	}
+}
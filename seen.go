@@ -0,0 +1,39 @@
+package leapmotion
+
+import "time"
+
+// lastSeenRetention is how long LastSeen remembers a hand after it was last
+// seen
+const lastSeenRetention = time.Minute
+
+// updateLastSeen records that the hands of frame were seen at, forgetting
+// hands gone for longer than lastSeenRetention. c.mu must be held.
+func (c *Client) updateLastSeen(frame *Frame, at time.Time) {
+	if c.lastSeen == nil {
+		c.lastSeen = make(map[int]time.Time)
+	}
+	for _, hand := range frame.Hands {
+		c.lastSeen[hand.ID] = at
+	}
+
+	for id, seen := range c.lastSeen {
+		if at.Sub(seen) > lastSeenRetention {
+			delete(c.lastSeen, id)
+		}
+	}
+}
+
+// LastSeen returns how long ago the hand with handID was last in a frame, by
+// the host clock, e.g. to keep a grabbed object held through a brief tracking
+// dropout. For a hand in the latest frame it's the age of that frame. The
+// bool is false if the hand hasn't been seen within the last minute.
+func (c *Client) LastSeen(handID int) (time.Duration, bool) {
+	c.mu.Lock()
+	seen, ok := c.lastSeen[handID]
+	c.mu.Unlock()
+
+	if !ok {
+		return 0, false
+	}
+	return time.Since(seen), true
+}
@@ -0,0 +1,31 @@
+package leapmotion
+
+import (
+	"testing"
+	"time"
+)
+
+func TestLastSeen(t *testing.T) {
+	c := newClient(nil, nil)
+
+	if _, ok := c.LastSeen(1); ok {
+		t.Fatal("Expected hand 1 not to have been seen")
+	}
+
+	now := time.Now()
+	c.handleMessageAt([]byte(`{"id": 1, "hands": [{"id": 1}, {"id": 2}]}`), now.Add(-200*time.Millisecond))
+	c.handleMessageAt([]byte(`{"id": 2, "hands": [{"id": 2}]}`), now)
+
+	if d, ok := c.LastSeen(1); !ok || d < 200*time.Millisecond {
+		t.Fatalf("Received %v, %t. Expected at least 200ms, true", d, ok)
+	}
+	if d, ok := c.LastSeen(2); !ok || d >= 200*time.Millisecond {
+		t.Fatalf("Received %v, %t. Expected less than 200ms, true", d, ok)
+	}
+
+	// Hands gone for longer than the retention are forgotten
+	c.handleMessageAt([]byte(`{"id": 3}`), now.Add(2*lastSeenRetention))
+	if _, ok := c.LastSeen(2); ok {
+		t.Fatal("Expected hand 2 to be forgotten")
+	}
+}
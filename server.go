@@ -0,0 +1,242 @@
+package leapmotion
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"sync"
+
+	"golang.org/x/net/websocket"
+)
+
+const (
+	// serverProtocolVersion is the protocol version a Server speaks
+	serverProtocolVersion = 6
+	// serverSendBuffer is the number of frames queued for a client before
+	// Broadcast drops frames for it
+	serverSendBuffer = 8
+)
+
+// Server serves frames to WebSocket clients in the format of the Leap Motion
+// service, e.g. for a relay passing frames on to browsers. Clients get the
+// handshake on connecting, and gestures only once they send enableGestures.
+// Requests to receive frames in the background are always granted; the Server
+// has no notion of focus.
+type Server struct {
+	// ServiceVersion is announced to clients in the handshake
+	ServiceVersion string
+
+	mu     sync.Mutex
+	closed bool
+	conns  map[*serverConn]struct{}
+}
+
+// serverConn is a client connected to a Server
+type serverConn struct {
+	transport Transport
+	sendMu    sync.Mutex  // serializes writes to transport
+	frames    chan []byte // queued by Broadcast, written by write
+	done      chan struct{}
+
+	mu       sync.Mutex
+	gestures bool
+}
+
+// NewServer returns a Server announcing serviceVersion to its clients
+func NewServer(serviceVersion string) *Server {
+	return &Server{
+		ServiceVersion: serviceVersion,
+		conns:          make(map[*serverConn]struct{}),
+	}
+}
+
+// ServeHTTP upgrades the request to a WebSocket and serves it until the client
+// disconnects. Requests of any origin are accepted.
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	websocket.Server{
+		Handshake: func(*websocket.Config, *http.Request) error {
+			return nil
+		},
+		Handler: func(conn *websocket.Conn) {
+			s.Serve(&websocketTransport{conn: conn})
+		},
+	}.ServeHTTP(w, r)
+}
+
+// Serve sends the handshake to the client connected through t, then handles
+// its configuration messages and streams it the broadcast frames until it
+// disconnects or the Server is closed. Use it to serve over another WebSocket
+// library than ServeHTTP does.
+func (s *Server) Serve(t Transport) error {
+	conn := &serverConn{
+		transport: t,
+		frames:    make(chan []byte, serverSendBuffer),
+		done:      make(chan struct{}),
+	}
+	defer t.Close()
+
+	// The handshake must be the first message, so the client is only
+	// broadcast to once it's sent
+	handshake := Handshake{ServiceVersion: s.ServiceVersion, Version: serverProtocolVersion}
+	if err := conn.send(handshake); err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	if s.closed {
+		s.mu.Unlock()
+		return ErrClosed
+	}
+	if s.conns == nil {
+		s.conns = make(map[*serverConn]struct{})
+	}
+	s.conns[conn] = struct{}{}
+	s.mu.Unlock()
+
+	go conn.write()
+	defer func() {
+		s.mu.Lock()
+		delete(s.conns, conn)
+		s.mu.Unlock()
+		close(conn.done)
+	}()
+
+	for {
+		raw, err := t.Receive()
+		if err != nil {
+			s.mu.Lock()
+			closed := s.closed
+			s.mu.Unlock()
+
+			if closed || err == io.EOF {
+				return nil
+			}
+			return err
+		}
+
+		if err := conn.configure(raw); err != nil {
+			return err
+		}
+	}
+}
+
+// configure applies a configuration message of the client, replying to a
+// background request. Messages other than enableGestures and
+// backgroundMessage, e.g. gesture parameters, are ignored.
+func (c *serverConn) configure(raw []byte) error {
+	var config map[string]interface{}
+	if err := json.Unmarshal(raw, &config); err != nil {
+		return nil
+	}
+
+	if enable, ok := config["enableGestures"].(bool); ok {
+		c.mu.Lock()
+		c.gestures = enable
+		c.mu.Unlock()
+	}
+
+	if background, ok := config["backgroundMessage"].(bool); ok {
+		return c.send(map[string]bool{"background": background})
+	}
+	return nil
+}
+
+func (c *serverConn) send(v interface{}) error {
+	msg, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	return c.sendRaw(msg)
+}
+
+func (c *serverConn) sendRaw(msg []byte) error {
+	c.sendMu.Lock()
+	defer c.sendMu.Unlock()
+
+	return c.transport.Send(msg)
+}
+
+// write sends the frames queued by Broadcast until Serve returns. A client
+// failing to receive a frame is disconnected.
+func (c *serverConn) write() {
+	for {
+		select {
+		case msg := <-c.frames:
+			if err := c.sendRaw(msg); err != nil {
+				c.transport.Close()
+				return
+			}
+		case <-c.done:
+			return
+		}
+	}
+}
+
+// queue hands msg to write, dropping it if the client is too slow to keep up
+func (c *serverConn) queue(msg []byte) {
+	select {
+	case c.frames <- msg:
+	default:
+	}
+}
+
+func (c *serverConn) wantsGestures() bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.gestures
+}
+
+// Broadcast queues frame for every connected client, without its gestures for
+// the clients that haven't enabled them. Every client is sent its frames on
+// its own, so a slow client doesn't hold up the others; frames are dropped
+// for a client that has 8 frames queued. Clients failing to receive a frame
+// are disconnected.
+func (s *Server) Broadcast(frame *Frame) error {
+	s.mu.Lock()
+	if s.closed {
+		s.mu.Unlock()
+		return ErrClosed
+	}
+	conns := make([]*serverConn, 0, len(s.conns))
+	for conn := range s.conns {
+		conns = append(conns, conn)
+	}
+	s.mu.Unlock()
+
+	full, err := json.Marshal(frame)
+	if err != nil {
+		return err
+	}
+	withoutGestures := *frame
+	withoutGestures.Gestures = []Gesture{}
+	stripped, err := json.Marshal(&withoutGestures)
+	if err != nil {
+		return err
+	}
+
+	for _, conn := range conns {
+		msg := stripped
+		if conn.wantsGestures() {
+			msg = full
+		}
+		conn.queue(msg)
+	}
+
+	return nil
+}
+
+// Close disconnects every client. Serve and Broadcast return ErrClosed
+// afterward.
+func (s *Server) Close() error {
+	s.mu.Lock()
+	s.closed = true
+	conns := s.conns
+	s.conns = nil
+	s.mu.Unlock()
+
+	for conn := range conns {
+		conn.transport.Close()
+	}
+	return nil
+}
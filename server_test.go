@@ -0,0 +1,185 @@
+package leapmotion
+
+import (
+	"encoding/json"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+)
+
+// waitSent waits for transport to have been sent n messages
+func waitSent(t *testing.T, transport *fakeTransport, n int) []string {
+	deadline := time.Now().Add(time.Second)
+	for {
+		sent := transport.sentMessages()
+		if len(sent) >= n {
+			return sent
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("Received %d messages. Expected %d", len(sent), n)
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+// waitClients waits for s to have n clients to broadcast to
+func waitClients(t *testing.T, s *Server, n int) {
+	deadline := time.Now().Add(time.Second)
+	for {
+		s.mu.Lock()
+		clients := len(s.conns)
+		s.mu.Unlock()
+		if clients >= n {
+			return
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("Received %d clients. Expected %d", clients, n)
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+// slowTransport is a fakeTransport whose Send blocks until it's closed
+type slowTransport struct {
+	*fakeTransport
+}
+
+func (t slowTransport) Send(msg []byte) error {
+	var handshake Handshake
+	if json.Unmarshal(msg, &handshake) == nil && handshake.Version != 0 {
+		return t.fakeTransport.Send(msg)
+	}
+	<-t.closed
+	return fmt.Errorf("closed")
+}
+
+func TestServer(t *testing.T) {
+	s := NewServer("2.3.1+33747")
+	transport := newFakeTransport()
+
+	served := make(chan error, 1)
+	go func() {
+		served <- s.Serve(transport)
+	}()
+
+	sent := waitSent(t, transport, 1)
+	var handshake Handshake
+	if err := json.Unmarshal([]byte(sent[0]), &handshake); err != nil || handshake.Version != 6 || handshake.ServiceVersion != "2.3.1+33747" {
+		t.Fatalf("Received %s. Expected the handshake", sent[0])
+	}
+	waitClients(t, s, 1)
+
+	frame := &Frame{ID: 1, Gestures: []Gesture{{ID: 3, Type: "swipe"}}}
+	if err := s.Broadcast(frame); err != nil {
+		t.Fatal(err)
+	}
+	sent = waitSent(t, transport, 2)
+	var received Frame
+	if err := json.Unmarshal([]byte(sent[1]), &received); err != nil || received.ID != 1 || len(received.Gestures) != 0 {
+		t.Fatalf("Received %s. Expected frame 1 without gestures", sent[1])
+	}
+
+	transport.messages <- []byte(`{"enableGestures": true}`)
+	transport.messages <- []byte(`{"backgroundMessage": true}`)
+	sent = waitSent(t, transport, 3)
+	if sent[2] != `{"background":true}` {
+		t.Fatalf("Received %s. Expected the background reply", sent[2])
+	}
+
+	if err := s.Broadcast(frame); err != nil {
+		t.Fatal(err)
+	}
+	sent = waitSent(t, transport, 4)
+	if err := json.Unmarshal([]byte(sent[3]), &received); err != nil || len(received.Gestures) != 1 {
+		t.Fatalf("Received %s. Expected frame 1 with its gesture", sent[3])
+	}
+
+	s.Close()
+	select {
+	case err := <-served:
+		if err != nil {
+			t.Fatal(err)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("Timed out waiting for Serve to return")
+	}
+
+	if err := s.Broadcast(frame); err != ErrClosed {
+		t.Fatalf("Received %v. Expected ErrClosed", err)
+	}
+}
+
+func TestServerBroadcastWhileServing(t *testing.T) {
+	s := NewServer("2.3.1+33747")
+
+	stop := make(chan struct{})
+	broadcasting := make(chan struct{})
+	go func() {
+		defer close(broadcasting)
+		for id := 1.0; ; id++ {
+			select {
+			case <-stop:
+				return
+			default:
+			}
+			s.Broadcast(&Frame{ID: id})
+		}
+	}()
+
+	var wg sync.WaitGroup
+	transports := make([]*fakeTransport, 20)
+	for i := range transports {
+		transports[i] = newFakeTransport()
+		wg.Add(1)
+		go func(transport *fakeTransport) {
+			defer wg.Done()
+			s.Serve(transport)
+		}(transports[i])
+	}
+
+	for _, transport := range transports {
+		sent := waitSent(t, transport, 2)
+		var handshake Handshake
+		if err := json.Unmarshal([]byte(sent[0]), &handshake); err != nil || handshake.Version != 6 {
+			t.Fatalf("Received %s. Expected the handshake first", sent[0])
+		}
+	}
+
+	close(stop)
+	<-broadcasting
+	s.Close()
+	wg.Wait()
+}
+
+func TestServerSlowClient(t *testing.T) {
+	s := NewServer("2.3.1+33747")
+	slow := slowTransport{newFakeTransport()}
+	fast := newFakeTransport()
+
+	var wg sync.WaitGroup
+	for _, transport := range []Transport{slow, fast} {
+		wg.Add(1)
+		go func(transport Transport) {
+			defer wg.Done()
+			s.Serve(transport)
+		}(transport)
+	}
+	waitClients(t, s, 2)
+
+	// Broadcast doesn't wait for the slow client, so the fast one keeps up
+	frames := serverSendBuffer * 4
+	for i := 1; i <= frames; i++ {
+		s.Broadcast(&Frame{ID: float64(i)})
+		waitSent(t, fast, i+1)
+	}
+
+	sent := fast.sentMessages()
+	var last Frame
+	if err := json.Unmarshal([]byte(sent[len(sent)-1]), &last); err != nil || last.ID != float64(frames) {
+		t.Fatalf("Received %s. Expected frame %d", sent[len(sent)-1], frames)
+	}
+
+	s.Close()
+	wg.Wait()
+}
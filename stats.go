@@ -0,0 +1,24 @@
+package leapmotion
+
+// Stats counts the frames a Client has processed
+type Stats struct {
+	// Frames is the number of frames received
+	Frames uint64
+	// Duplicates is the number of frames dropped by WithDedup for repeating
+	// the ID of the previous frame
+	Duplicates uint64
+	// Rejected is the number of frames dropped by WithSanityCheck for
+	// impossible values
+	Rejected uint64
+	// Truncated is the number of messages dropped for being JSON cut short,
+	// each also reported with ErrTruncatedMessage on the Errors channel
+	Truncated uint64
+}
+
+// Stats returns the counts of the frames processed so far
+func (c *Client) Stats() Stats {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.stats
+}
@@ -0,0 +1,19 @@
+package leapmotion
+
+import "testing"
+
+func TestDedup(t *testing.T) {
+	var ids []float64
+	c := newClient(func(frame *Frame) { ids = append(ids, frame.ID) }, []Option{WithDedup(true)})
+
+	for _, raw := range []string{`{"id": 1}`, `{"id": 1}`, `{"id": 2}`, `{"id": 2}`, `{"id": 1}`} {
+		c.handleMessage([]byte(raw))
+	}
+
+	if len(ids) != 3 || ids[0] != 1 || ids[1] != 2 || ids[2] != 1 {
+		t.Fatalf("Received frames %v. Expected [1 2 1]", ids)
+	}
+	if stats := c.Stats(); stats.Frames != 5 || stats.Duplicates != 2 {
+		t.Fatalf("Received %+v. Expected 5 frames and 2 duplicates", stats)
+	}
+}
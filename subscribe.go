@@ -0,0 +1,42 @@
+package leapmotion
+
+import "sync"
+
+// subscribers fans delivered frames out to consumers other than the
+// frameHandler
+type subscribers struct {
+	mu   sync.Mutex
+	subs map[chan *Frame]struct{}
+}
+
+// subscribe returns a channel receiving every delivered frame and a function
+// to stop the subscription. Frames are dropped rather than blocking processing
+// when the channel's buffer is full.
+func (c *Client) subscribe() (<-chan *Frame, func()) {
+	ch := make(chan *Frame, messagesBuffer)
+
+	c.subs.mu.Lock()
+	if c.subs.subs == nil {
+		c.subs.subs = make(map[chan *Frame]struct{})
+	}
+	c.subs.subs[ch] = struct{}{}
+	c.subs.mu.Unlock()
+
+	return ch, func() {
+		c.subs.mu.Lock()
+		delete(c.subs.subs, ch)
+		c.subs.mu.Unlock()
+	}
+}
+
+func (s *subscribers) publish(frame *Frame) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for ch := range s.subs {
+		select {
+		case ch <- frame:
+		default:
+		}
+	}
+}
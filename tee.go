@@ -0,0 +1,34 @@
+package leapmotion
+
+import "io"
+
+// Tee writes every message received from now on to w, one per line, in
+// addition to handling it, e.g. to capture the exact stream a user saw for a
+// bug report. The messages are written as JSON, after WithMessageDecoder, so
+// the capture can be replayed with ConnectFile. Writes happen on the message
+// loop, so a slow w slows down frame handling; failed writes are reported on
+// the Errors channel.
+func (c *Client) Tee(w io.Writer) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.tees = append(c.tees, w)
+}
+
+// tee writes raw to the writers added with Tee
+func (c *Client) tee(raw []byte) {
+	c.mu.Lock()
+	tees := c.tees
+	c.mu.Unlock()
+
+	if len(tees) == 0 {
+		return
+	}
+
+	line := append(raw[:len(raw):len(raw)], '\n')
+	for _, w := range tees {
+		if _, err := w.Write(line); err != nil {
+			c.reportError(err)
+		}
+	}
+}
@@ -0,0 +1,76 @@
+package leapmotion
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+type failingWriter struct{}
+
+func (failingWriter) Write([]byte) (int, error) {
+	return 0, errors.New("disk full")
+}
+
+func TestTee(t *testing.T) {
+	c := newClient(nil, nil)
+
+	var capture bytes.Buffer
+	c.Tee(&capture)
+
+	messages := []string{
+		`{"serviceVersion": "2.3.1+33747", "version": 6}`,
+		`{"id": 1, "timestamp": 0}`,
+		`{"id": 2, "timestamp": 1000}`,
+	}
+	for _, m := range messages {
+		c.handleMessage([]byte(m))
+	}
+
+	expected := messages[0] + "\n" + messages[1] + "\n" + messages[2] + "\n"
+	if capture.String() != expected {
+		t.Fatalf("Received %q. Expected %q", capture.String(), expected)
+	}
+
+	// The capture replays
+	path := filepath.Join(t.TempDir(), "capture.json")
+	if err := os.WriteFile(path, capture.Bytes(), 0644); err != nil {
+		t.Fatal(err)
+	}
+	frames := make(chan *Frame, 2)
+	replay, err := ConnectFile(path, func(frame *Frame) { frames <- frame })
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer replay.Close()
+
+	for _, id := range []float64{1, 2} {
+		select {
+		case frame := <-frames:
+			if frame.ID != id {
+				t.Fatalf("Received frame %v. Expected %v", frame.ID, id)
+			}
+		case <-time.After(time.Second):
+			t.Fatal("Timed out waiting for the replay")
+		}
+	}
+}
+
+func TestTeeWriteError(t *testing.T) {
+	c := newClient(nil, nil)
+	c.Tee(failingWriter{})
+
+	c.handleMessage([]byte(`{"id": 1}`))
+
+	select {
+	case err := <-c.Errors():
+		if err.Error() != "disk full" {
+			t.Fatalf("Received %v. Expected disk full", err)
+		}
+	default:
+		t.Fatal("Expected the write error on Errors")
+	}
+}
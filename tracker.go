@@ -0,0 +1,240 @@
+package leapmotion
+
+import "time"
+
+// HandTracker maps the transient hand IDs reported by the Leap Motion service
+// to stable logical IDs. When tracking of a hand drops and is re-acquired the
+// service issues a new ID; the tracker gives the hand back its old logical ID
+// if it reappears close to where it vanished within a short window.
+type HandTracker struct {
+	// MaxDistance is how far (in millimeters) from where it vanished a hand
+	// may reappear and still be given its previous logical ID
+	MaxDistance float64
+	// Window is how long the logical ID of a vanished hand is kept for reuse
+	Window time.Duration
+
+	detector[*trackedHand] // the hands being tracked, keyed by Leap hand ID
+	nextID                 int
+	lost                   []*trackedHand
+}
+
+type trackedHand struct {
+	logicalID int
+	handType  string
+	position  []float64
+	lostAt    int // frame timestamp (microseconds) the hand vanished at
+
+	// The two latest samples of the strengths, for their rates of change
+	samples int
+	last    strengthSample
+	prev    strengthSample
+}
+
+type strengthSample struct {
+	timestamp int
+	grab      float64
+	pinch     float64
+}
+
+// NewHandTracker returns a HandTracker that reissues logical IDs to hands
+// reappearing within maxDistance millimeters and window of vanishing
+func NewHandTracker(maxDistance float64, window time.Duration) *HandTracker {
+	return &HandTracker{
+		MaxDistance: maxDistance,
+		Window:      window,
+	}
+}
+
+// StableID returns the logical ID of the hand with handID in frame. The frame
+// is fed to the tracker the first time it is seen, so StableID can be called
+// for every hand of a frame. It returns -1 if the hand isn't in the frame.
+func (t *HandTracker) StableID(frame *Frame, handID int) int {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.update(frame)
+
+	if h, ok := t.hands[handID]; ok {
+		return h.logicalID
+	}
+	return -1
+}
+
+// GrabRate returns the rate of change, per second, of the grab strength of the
+// hand with handID in frame between the last two frames the hand was tracked
+// in. A quick grab shows as a spike, a deliberate one as a slow ramp. Samples
+// follow the hand's logical ID, so the rate survives a change of Leap ID. The
+// bool is false until the hand has been seen in two frames.
+func (t *HandTracker) GrabRate(frame *Frame, handID int) (float64, bool) {
+	return t.strengthRate(frame, handID, func(s strengthSample) float64 { return s.grab })
+}
+
+// PinchRate returns the rate of change, per second, of the pinch strength of
+// the hand with handID in frame, like GrabRate
+func (t *HandTracker) PinchRate(frame *Frame, handID int) (float64, bool) {
+	return t.strengthRate(frame, handID, func(s strengthSample) float64 { return s.pinch })
+}
+
+func (t *HandTracker) strengthRate(frame *Frame, handID int, strength func(strengthSample) float64) (float64, bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.update(frame)
+
+	h, ok := t.hands[handID]
+	if !ok || h.samples < 2 || h.last.timestamp <= h.prev.timestamp {
+		return 0, false
+	}
+
+	dt := float64(h.last.timestamp-h.prev.timestamp) / float64(time.Second/time.Microsecond)
+	return (strength(h.last) - strength(h.prev)) / dt, true
+}
+
+// Update feeds frame to the tracker. It only needs to be called directly when
+// frames may pass without a call to StableID.
+func (t *HandTracker) Update(frame *Frame) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.update(frame)
+}
+
+func (t *HandTracker) update(frame *Frame) {
+	if !t.next(frame) {
+		return
+	}
+
+	// Forget hands that vanished longer than Window ago
+	window := int(t.Window / time.Microsecond)
+	lost := t.lost[:0]
+	for _, h := range t.lost {
+		if frame.Timestamp-h.lostAt <= window {
+			lost = append(lost, h)
+		}
+	}
+	t.lost = lost
+
+	for i := range frame.Hands {
+		hand := &frame.Hands[i]
+
+		h, ok := t.hands[hand.ID]
+		if ok {
+			if isVector(hand.PalmPosition) {
+				h.position = hand.PalmPosition
+			}
+		} else {
+			h = t.reacquire(hand)
+			if h == nil {
+				t.nextID++
+				h = &trackedHand{logicalID: t.nextID, handType: hand.Type}
+			}
+			h.position = hand.PalmPosition
+			t.hands.set(hand.ID, h)
+		}
+
+		h.prev = h.last
+		h.last = strengthSample{frame.Timestamp, hand.GrabStrength, hand.PinchStrength}
+		h.samples++
+	}
+
+	t.hands.forgetAbsent(frame, func(id int, h *trackedHand) {
+		h.lostAt = frame.Timestamp
+		t.lost = append(t.lost, h)
+	})
+}
+
+// reacquire removes and returns the vanished hand closest to hand, if any is
+// within MaxDistance
+func (t *HandTracker) reacquire(hand *Hand) *trackedHand {
+	if !isVector(hand.PalmPosition) {
+		return nil
+	}
+
+	best := -1
+	bestDistance := t.MaxDistance
+	for i, h := range t.lost {
+		if h.handType != hand.Type || !isVector(h.position) {
+			continue
+		}
+		if d := distance(h.position, hand.PalmPosition); d <= bestDistance {
+			best = i
+			bestDistance = d
+		}
+	}
+	if best < 0 {
+		return nil
+	}
+
+	h := t.lost[best]
+	t.lost = append(t.lost[:best], t.lost[best+1:]...)
+	return h
+}
+
+// TypeTracker debounces the left/right labels of hands, which can flip for a
+// frame when a hand is at the edge of the field of view. A hand's stable type
+// only changes once it has been labelled the other way for Frames consecutive
+// frames. Feed it every frame with Update and read labels with Hand.StableType.
+type TypeTracker struct {
+	// Frames is how many consecutive frames a new label must be seen for
+	Frames int
+
+	detector[*handType] // keyed by Leap hand ID
+}
+
+type handType struct {
+	stable    string
+	candidate string
+	count     int
+}
+
+// NewTypeTracker returns a TypeTracker that flips the type of a hand after
+// frames consecutive frames of the other label
+func NewTypeTracker(frames int) *TypeTracker {
+	return &TypeTracker{Frames: frames}
+}
+
+// Update feeds frame to the tracker. Feeding the same frame twice has no effect.
+func (t *TypeTracker) Update(frame *Frame) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if !t.next(frame) {
+		return
+	}
+
+	for _, hand := range frame.Hands {
+		h, ok := t.hands[hand.ID]
+		switch {
+		case !ok:
+			t.hands.set(hand.ID, &handType{stable: hand.Type})
+			continue
+		case hand.Type == h.stable:
+			h.count = 0
+			continue
+		case hand.Type == h.candidate && h.count > 0:
+			h.count++
+		default:
+			h.candidate = hand.Type
+			h.count = 1
+		}
+
+		if h.count >= t.Frames {
+			h.stable = h.candidate
+			h.count = 0
+		}
+	}
+
+	t.hands.forgetAbsent(frame, nil)
+}
+
+// StableType returns the debounced type, "left" or "right", of the hand. It is
+// the hand's own Type if the tracker hasn't seen the hand.
+func (h *Hand) StableType(t *TypeTracker) string {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if ht, ok := t.hands[h.ID]; ok {
+		return ht.stable
+	}
+	return h.Type
+}
@@ -0,0 +1,79 @@
+package leapmotion
+
+import (
+	"testing"
+	"time"
+)
+
+func TestHandTrackerStableID(t *testing.T) {
+	tracker := NewHandTracker(50, 500*time.Millisecond)
+
+	hand := func(id int, x float64) Hand {
+		return Hand{ID: id, Type: "right", PalmPosition: []float64{x, 200, 0}}
+	}
+
+	frames := []struct {
+		frame    *Frame
+		handID   int
+		expected int
+	}{
+		{testFrame(1, 0, hand(10, 0)), 10, 1},
+		{testFrame(2, 10000, hand(10, 5)), 10, 1},
+		// Tracking drops out
+		{testFrame(3, 20000), 10, -1},
+		// The hand comes back with a new Leap ID close to where it vanished
+		{testFrame(4, 100000, hand(11, 20)), 11, 1},
+		// A hand far away is a new hand
+		{testFrame(5, 110000, hand(11, 20), hand(12, 300)), 12, 2},
+		{testFrame(6, 120000), 11, -1},
+		// Reappearing after the window gets a new logical ID
+		{testFrame(7, 900000, hand(13, 20)), 13, 3},
+	}
+
+	for _, test := range frames {
+		if id := tracker.StableID(test.frame, test.handID); id != test.expected {
+			t.Fatalf("Frame %v: received %d. Expected %d", test.frame.ID, id, test.expected)
+		}
+	}
+}
+
+func TestStableType(t *testing.T) {
+	tracker := NewTypeTracker(3)
+
+	types := []string{"left", "right", "left", "right", "right", "right", "left"}
+	expected := []string{"left", "left", "left", "left", "left", "right", "right"}
+
+	for i, handType := range types {
+		frame := testFrame(i, i*10000, Hand{ID: 1, Type: handType})
+		tracker.Update(frame)
+
+		if stable := frame.Hands[0].StableType(tracker); stable != expected[i] {
+			t.Fatalf("Frame %d: received %s. Expected %s", i, stable, expected[i])
+		}
+	}
+}
+
+func TestHandTrackerGrabRate(t *testing.T) {
+	tracker := NewHandTracker(50, time.Second)
+
+	hand := func(id int, grab float64) Hand {
+		return Hand{ID: id, Type: "left", GrabStrength: grab, PinchStrength: grab / 2, PalmPosition: []float64{0, 200, 0}}
+	}
+
+	first := testFrame(1, 0, hand(1, 0.2))
+	if _, ok := tracker.GrabRate(first, 1); ok {
+		t.Fatal("Expected no rate from a single frame")
+	}
+
+	// The hand is re-acquired with a new ID 100ms later
+	tracker.Update(testFrame(2, 50000))
+	second := testFrame(3, 100000, hand(2, 0.7))
+
+	rate, ok := tracker.GrabRate(second, 2)
+	if !ok || rate < 4.999 || rate > 5.001 {
+		t.Fatalf("Received %f, %t. Expected 5, true", rate, ok)
+	}
+	if rate, ok := tracker.PinchRate(second, 2); !ok || rate < 2.499 || rate > 2.501 {
+		t.Fatalf("Received %f, %t. Expected 2.5, true", rate, ok)
+	}
+}
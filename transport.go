@@ -0,0 +1,77 @@
+package leapmotion
+
+import (
+	"net/http"
+	"time"
+
+	"golang.org/x/net/websocket"
+)
+
+// Transport is the connection a Client receives messages from and sends
+// messages to. The default Transport is a golang.org/x/net/websocket
+// connection; use WithDialer to plug in another WebSocket library or a mock.
+type Transport interface {
+	// Send writes a text message
+	Send(msg []byte) error
+	// Receive blocks until a message is read
+	Receive() ([]byte, error)
+	// Close closes the connection, unblocking Receive
+	Close() error
+}
+
+// DialFunc opens a Transport to the Leap Motion WebSocket server at address
+type DialFunc func(address string) (Transport, error)
+
+type websocketTransport struct {
+	conn *websocket.Conn
+}
+
+// dialWebSocketConfig dials address with the extra handshake headers and
+// subprotocols set with WithHeader and WithSubprotocol
+func dialWebSocketConfig(address string, header http.Header, protocols []string) (Transport, error) {
+	config, err := webSocketConfig(address, header, protocols)
+	if err != nil {
+		return nil, err
+	}
+
+	conn, err := websocket.DialConfig(config)
+	if err != nil {
+		return nil, err
+	}
+	return &websocketTransport{conn: conn}, nil
+}
+
+func webSocketConfig(address string, header http.Header, protocols []string) (*websocket.Config, error) {
+	config, err := websocket.NewConfig(address, "http://localhost/")
+	if err != nil {
+		return nil, err
+	}
+
+	for key, values := range header {
+		for _, value := range values {
+			config.Header.Add(key, value)
+		}
+	}
+	config.Protocol = protocols
+
+	return config, nil
+}
+
+func (t *websocketTransport) Send(msg []byte) error {
+	return websocket.Message.Send(t.conn, string(msg))
+}
+
+func (t *websocketTransport) Receive() ([]byte, error) {
+	var msg []byte
+	err := websocket.Message.Receive(t.conn, &msg)
+	return msg, err
+}
+
+func (t *websocketTransport) Close() error {
+	return t.conn.Close()
+}
+
+// SetReadDeadline lets StopProcessing interrupt a blocked Receive
+func (t *websocketTransport) SetReadDeadline(deadline time.Time) error {
+	return t.conn.SetReadDeadline(deadline)
+}
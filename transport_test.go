@@ -0,0 +1,292 @@
+package leapmotion
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"sync"
+	"testing"
+	"time"
+)
+
+// fakeTransport is a Transport that replays queued messages and records the
+// messages sent to it
+type fakeTransport struct {
+	messages  chan []byte
+	errs      chan error    // errors for Receive to return
+	wake      chan struct{} // interrupts Receive like an expired read deadline
+	closed    chan struct{}
+	closeOnce sync.Once
+
+	mu   sync.Mutex
+	sent []string
+}
+
+func newFakeTransport(messages ...string) *fakeTransport {
+	t := &fakeTransport{
+		messages: make(chan []byte, 100),
+		errs:     make(chan error, 10),
+		wake:     make(chan struct{}, 1),
+		closed:   make(chan struct{}),
+	}
+	for _, m := range messages {
+		t.messages <- []byte(m)
+	}
+	return t
+}
+
+func (t *fakeTransport) dialer() DialFunc {
+	return func(string) (Transport, error) {
+		return t, nil
+	}
+}
+
+func (t *fakeTransport) Send(msg []byte) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.sent = append(t.sent, string(msg))
+	return nil
+}
+
+func (t *fakeTransport) Receive() ([]byte, error) {
+	select {
+	case m := <-t.messages:
+		return m, nil
+	case err := <-t.errs:
+		return nil, err
+	case <-t.wake:
+		return nil, errors.New("i/o timeout")
+	case <-t.closed:
+		return nil, io.EOF
+	}
+}
+
+func (t *fakeTransport) SetReadDeadline(deadline time.Time) error {
+	if !deadline.IsZero() {
+		select {
+		case t.wake <- struct{}{}:
+		default:
+		}
+	}
+	return nil
+}
+
+func (t *fakeTransport) Close() error {
+	t.closeOnce.Do(func() { close(t.closed) })
+	return nil
+}
+
+func (t *fakeTransport) sentMessages() []string {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	return append([]string(nil), t.sent...)
+}
+
+func TestConnectWithDialer(t *testing.T) {
+	transport := newFakeTransport(`{"id": 1, "timestamp": 10, "hands": [{"id": 5}]}`)
+
+	frames := make(chan *Frame, 1)
+	c, err := Connect(func(frame *Frame) {
+		frames <- frame
+	}, WithDialer(transport.dialer()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer c.Close()
+
+	select {
+	case frame := <-frames:
+		if len(frame.Hands) != 1 || frame.Hands[0].ID != 5 {
+			t.Fatalf("Received %v. Expected a frame with hand 5", frame)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("Timed out waiting for a frame")
+	}
+
+	sent := transport.sentMessages()
+	if len(sent) != 2 {
+		t.Fatalf("Received %d setup messages. Expected 2", len(sent))
+	}
+	var setup map[string]bool
+	if err := json.Unmarshal([]byte(sent[0]), &setup); err != nil || !setup["enableGestures"] {
+		t.Fatalf("Received %s. Expected enableGestures", sent[0])
+	}
+}
+
+func TestIdleFrames(t *testing.T) {
+	transport := newFakeTransport()
+
+	frames := make(chan *Frame, 10)
+	c, err := Connect(func(frame *Frame) {
+		frames <- frame
+	}, WithDialer(transport.dialer()), WithIdleFrames(10*time.Millisecond))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer c.Close()
+
+	select {
+	case frame := <-frames:
+		if !frame.Synthetic {
+			t.Fatalf("Received %v. Expected a synthetic frame", frame)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("Timed out waiting for an idle frame")
+	}
+
+	transport.messages <- []byte(`{"id": 1}`)
+	for frame := range frames {
+		if !frame.Synthetic {
+			break
+		}
+	}
+}
+
+func TestStopStartProcessing(t *testing.T) {
+	transport := newFakeTransport()
+
+	frames := make(chan *Frame, 10)
+	c, err := Connect(func(frame *Frame) {
+		frames <- frame
+	}, WithDialer(transport.dialer()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer c.Close()
+
+	c.StopProcessing()
+
+	// While stopped the messages are left on the connection
+	transport.messages <- []byte(`{"id": 1}`)
+	select {
+	case frame := <-frames:
+		t.Fatalf("Received %v while processing was stopped", frame)
+	case <-c.Done():
+		t.Fatal("Expected Done to stay open while processing is stopped")
+	case <-time.After(20 * time.Millisecond):
+	}
+
+	c.StartProcessing()
+	select {
+	case frame := <-frames:
+		if frame.ID != 1 {
+			t.Fatalf("Received %v. Expected frame 1", frame)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("Timed out waiting for a frame after StartProcessing")
+	}
+}
+
+func TestWebSocketConfig(t *testing.T) {
+	o := &options{}
+	WithHeader("Authorization", "Bearer token")(o)
+	WithSubprotocol("leap")(o)
+
+	config, err := webSocketConfig(defaultLeapWebSocketAddress, o.header, o.protocols)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if auth := config.Header.Get("Authorization"); auth != "Bearer token" {
+		t.Fatalf("Received %q. Expected %q", auth, "Bearer token")
+	}
+	if len(config.Protocol) != 1 || config.Protocol[0] != "leap" {
+		t.Fatalf("Received %v. Expected [leap]", config.Protocol)
+	}
+}
+
+func TestMaxReceiveErrors(t *testing.T) {
+	transport := newFakeTransport()
+
+	frames := make(chan *Frame, 10)
+	c, err := Connect(func(frame *Frame) {
+		frames <- frame
+	}, WithDialer(transport.dialer()), WithMaxReceiveErrors(3))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer c.Close()
+
+	// A message in between resets the count
+	transport.errs <- errors.New("transient")
+	transport.errs <- errors.New("transient")
+	transport.messages <- []byte(`{"id": 1}`)
+	select {
+	case <-frames:
+	case <-time.After(time.Second):
+		t.Fatal("Timed out waiting for a frame")
+	}
+
+	for i := 0; i < 3; i++ {
+		transport.errs <- errors.New("broken")
+	}
+
+	select {
+	case <-c.Done():
+	case <-time.After(time.Second):
+		t.Fatal("Timed out waiting for Done")
+	}
+
+	if err := <-c.Errors(); !errors.Is(err, ErrReceiveFailed) {
+		t.Fatalf("Received %v. Expected ErrReceiveFailed", err)
+	}
+}
+
+func TestClosedConnectionEndsReceiving(t *testing.T) {
+	transport := newFakeTransport()
+
+	c, err := Connect(nil, WithDialer(transport.dialer()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer c.Close()
+
+	closed := fmt.Errorf("read tcp 127.0.0.1:6437: %w", net.ErrClosed)
+	transport.errs <- closed
+
+	select {
+	case <-c.Done():
+	case <-time.After(time.Second):
+		t.Fatal("Timed out waiting for Done")
+	}
+
+	if err := <-c.Errors(); err != closed {
+		t.Fatalf("Received %v. Expected %v", err, closed)
+	}
+}
+
+func TestWithGesturesAndBackgroundMessages(t *testing.T) {
+	tests := []struct {
+		opts     []Option
+		expected []string
+	}{
+		{nil, []string{`{"enableGestures":true}`, `{"backgroundMessage":true}`}},
+		{[]Option{WithGestures(false)}, []string{`{"backgroundMessage":true}`}},
+		{[]Option{WithBackgroundMessages(false)}, []string{`{"enableGestures":true}`}},
+		{[]Option{WithGestures(false), WithBackgroundMessages(false)}, nil},
+		{[]Option{WithGestures(false), WithGestures(true)}, []string{`{"enableGestures":true}`, `{"backgroundMessage":true}`}},
+	}
+
+	for _, test := range tests {
+		transport := newFakeTransport()
+		c, err := Connect(nil, append(test.opts, WithDialer(transport.dialer()))...)
+		if err != nil {
+			t.Fatal(err)
+		}
+		c.Close()
+
+		sent := transport.sentMessages()
+		if len(sent) != len(test.expected) {
+			t.Fatalf("Received %v. Expected %v", sent, test.expected)
+		}
+		for i := range sent {
+			if sent[i] != test.expected[i] {
+				t.Fatalf("Received %v. Expected %v", sent, test.expected)
+			}
+		}
+	}
+}
@@ -0,0 +1,42 @@
+package leapmotion
+
+// Leap Motion positions and distances are in millimeters
+const (
+	millimetersPerCentimeter = 10
+	millimetersPerInch       = 25.4
+)
+
+// ToCentimeters converts a position or vector from millimeters to
+// centimeters. v isn't modified.
+func ToCentimeters(v []float64) []float64 {
+	return scale(v, 1.0/millimetersPerCentimeter)
+}
+
+// FromCentimeters converts a position or vector from centimeters to
+// millimeters. v isn't modified.
+func FromCentimeters(v []float64) []float64 {
+	return scale(v, millimetersPerCentimeter)
+}
+
+// ToInches converts a position or vector from millimeters to inches. v
+// isn't modified.
+func ToInches(v []float64) []float64 {
+	return scale(v, 1/millimetersPerInch)
+}
+
+// FromInches converts a position or vector from inches to millimeters. v
+// isn't modified.
+func FromInches(v []float64) []float64 {
+	return scale(v, millimetersPerInch)
+}
+
+func scale(v []float64, factor float64) []float64 {
+	if v == nil {
+		return nil
+	}
+	scaled := make([]float64, len(v))
+	for i := range v {
+		scaled[i] = v[i] * factor
+	}
+	return scaled
+}
@@ -0,0 +1,28 @@
+package leapmotion
+
+import (
+	"math"
+	"testing"
+)
+
+func TestUnitConversions(t *testing.T) {
+	v := []float64{254, -25.4, 0}
+
+	if cm := ToCentimeters(v); math.Abs(cm[0]-25.4) > 1e-9 || math.Abs(cm[1]+2.54) > 1e-9 || cm[2] != 0 {
+		t.Fatalf("Received %f. Expected [25.4 -2.54 0]", cm)
+	}
+	if in := ToInches(v); math.Abs(in[0]-10) > 1e-9 || math.Abs(in[1]+1) > 1e-9 {
+		t.Fatalf("Received %f. Expected [10 -1 0]", in)
+	}
+
+	for i, p := range FromInches(ToInches(v)) {
+		if math.Abs(p-v[i]) > 1e-9 {
+			t.Fatalf("Received %f. Expected %f", p, v[i])
+		}
+	}
+	for i, p := range FromCentimeters(ToCentimeters(v)) {
+		if math.Abs(p-v[i]) > 1e-9 {
+			t.Fatalf("Received %f. Expected %f", p, v[i])
+		}
+	}
+}
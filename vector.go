@@ -0,0 +1,173 @@
+package leapmotion
+
+import "math"
+
+// distance returns the euclidean distance between the first three
+// components of a and b
+func distance(a, b []float64) float64 {
+	return math.Sqrt(distanceSquared(a, b))
+}
+
+// distanceSquared returns the square of the distance between a and b, for
+// comparing against a squared threshold without a square root
+func distanceSquared(a, b []float64) float64 {
+	dx := a[0] - b[0]
+	dy := a[1] - b[1]
+	dz := a[2] - b[2]
+	return dx*dx + dy*dy + dz*dz
+}
+
+// isVector reports whether v has the three components of a Leap vector
+func isVector(v []float64) bool {
+	return len(v) >= 3
+}
+
+// normalize returns v scaled to unit length, or nil if v has no length
+func normalize(v []float64) []float64 {
+	length := math.Sqrt(v[0]*v[0] + v[1]*v[1] + v[2]*v[2])
+	if length == 0 {
+		return nil
+	}
+	return []float64{v[0] / length, v[1] / length, v[2] / length}
+}
+
+// copyVector returns a copy of v, or nil if v isn't a vector
+func copyVector(v []float64) []float64 {
+	if !isVector(v) {
+		return nil
+	}
+	return []float64{v[0], v[1], v[2]}
+}
+
+// Vector is a three component Leap vector, e.g. a position in millimeters
+type Vector [3]float64
+
+// toVector converts a vector field of a frame, returning the zero Vector if v
+// doesn't have three components
+func toVector(v []float64) Vector {
+	if !isVector(v) {
+		return Vector{}
+	}
+	return Vector{v[0], v[1], v[2]}
+}
+
+// X returns the first component of v
+func (v Vector) X() float64 { return v[0] }
+
+// Y returns the second component of v
+func (v Vector) Y() float64 { return v[1] }
+
+// Z returns the third component of v
+func (v Vector) Z() float64 { return v[2] }
+
+// Add returns v + w
+func (v Vector) Add(w Vector) Vector {
+	return Vector{v[0] + w[0], v[1] + w[1], v[2] + w[2]}
+}
+
+// Sub returns v - w
+func (v Vector) Sub(w Vector) Vector {
+	return Vector{v[0] - w[0], v[1] - w[1], v[2] - w[2]}
+}
+
+// Scale returns v multiplied by s
+func (v Vector) Scale(s float64) Vector {
+	return Vector{v[0] * s, v[1] * s, v[2] * s}
+}
+
+// Dot returns the dot product of v and w
+func (v Vector) Dot(w Vector) float64 {
+	return v[0]*w[0] + v[1]*w[1] + v[2]*w[2]
+}
+
+// Cross returns the cross product of v and w
+func (v Vector) Cross(w Vector) Vector {
+	return Vector{
+		v[1]*w[2] - v[2]*w[1],
+		v[2]*w[0] - v[0]*w[2],
+		v[0]*w[1] - v[1]*w[0],
+	}
+}
+
+// Length returns the euclidean length of v
+func (v Vector) Length() float64 {
+	return math.Sqrt(v.Dot(v))
+}
+
+// Distance returns the euclidean distance between v and w
+func (v Vector) Distance(w Vector) float64 {
+	return v.Sub(w).Length()
+}
+
+// Slice returns v as a slice, in the format of the fields of a frame
+func (v Vector) Slice() []float64 {
+	return []float64{v[0], v[1], v[2]}
+}
+
+// PalmPositionV returns PalmPosition as a Vector, zero if it isn't set
+func (h *Hand) PalmPositionV() Vector { return toVector(h.PalmPosition) }
+
+// PalmVelocityV returns PalmVelocity as a Vector, zero if it isn't set
+func (h *Hand) PalmVelocityV() Vector { return toVector(h.PalmVelocity) }
+
+// PalmNormalV returns PalmNormal as a Vector, zero if it isn't set
+func (h *Hand) PalmNormalV() Vector { return toVector(h.PalmNormal) }
+
+// DirectionV returns Direction as a Vector, zero if it isn't set
+func (h *Hand) DirectionV() Vector { return toVector(h.Direction) }
+
+// StabilizedPalmPositionV returns StabilizedPalmPosition as a Vector, zero if
+// it isn't set
+func (h *Hand) StabilizedPalmPositionV() Vector { return toVector(h.StabilizedPalmPosition) }
+
+// SphereCenterV returns SphereCenter as a Vector, zero if it isn't set
+func (h *Hand) SphereCenterV() Vector { return toVector(h.SphereCenter) }
+
+// WristV returns Wrist as a Vector, zero if it isn't set
+func (h *Hand) WristV() Vector { return toVector(h.Wrist) }
+
+// ElbowV returns Elbow as a Vector, zero if it isn't set
+func (h *Hand) ElbowV() Vector { return toVector(h.Elbow) }
+
+// TipPositionV returns TipPosition as a Vector, zero if it isn't set
+func (p *Pointable) TipPositionV() Vector { return toVector(p.TipPosition) }
+
+// TipVelocityV returns TipVelocity as a Vector, zero if it isn't set
+func (p *Pointable) TipVelocityV() Vector { return toVector(p.TipVelocity) }
+
+// StabilizedTipPositionV returns StabilizedTipPosition as a Vector, zero if it
+// isn't set
+func (p *Pointable) StabilizedTipPositionV() Vector { return toVector(p.StabilizedTipPosition) }
+
+// DirectionV returns Direction as a Vector, zero if it isn't set
+func (p *Pointable) DirectionV() Vector { return toVector(p.Direction) }
+
+// BtipPositionV returns BtipPosition as a Vector, zero if it isn't set
+func (p *Pointable) BtipPositionV() Vector { return toVector(p.BtipPosition) }
+
+// DipPositionV returns DipPosition as a Vector, zero if it isn't set
+func (p *Pointable) DipPositionV() Vector { return toVector(p.DipPosition) }
+
+// PipPositionV returns PipPosition as a Vector, zero if it isn't set
+func (p *Pointable) PipPositionV() Vector { return toVector(p.PipPosition) }
+
+// McpPositionV returns McpPosition as a Vector, zero if it isn't set
+func (p *Pointable) McpPositionV() Vector { return toVector(p.McpPosition) }
+
+// CarpPositionV returns CarpPosition as a Vector, zero if it isn't set
+func (p *Pointable) CarpPositionV() Vector { return toVector(p.CarpPosition) }
+
+// PositionV returns Position as a Vector, zero if it isn't set
+func (g *Gesture) PositionV() Vector { return toVector(g.Position) }
+
+// StartPositionV returns StartPosition as a Vector, zero if it isn't set
+func (g *Gesture) StartPositionV() Vector { return toVector(g.StartPosition) }
+
+// DirectionV returns Direction as a Vector, zero if it isn't set
+func (g *Gesture) DirectionV() Vector { return toVector(g.Direction) }
+
+// CenterV returns Center as a Vector, zero if it isn't set
+func (g *Gesture) CenterV() Vector { return toVector(g.Center) }
+
+// NormalV returns Normal as a Vector, zero if it isn't set
+func (g *Gesture) NormalV() Vector { return toVector(g.Normal) }
@@ -0,0 +1,44 @@
+package leapmotion
+
+import "testing"
+
+func TestVectorAccessors(t *testing.T) {
+	hand := &Hand{PalmPosition: []float64{1, 2, 3}, PalmVelocity: []float64{1, 2}}
+
+	if v := hand.PalmPositionV(); v != (Vector{1, 2, 3}) {
+		t.Fatalf("Received %v. Expected [1 2 3]", v)
+	}
+	if v := hand.PalmVelocityV(); v != (Vector{}) {
+		t.Fatalf("Received %v. Expected the zero Vector for a short slice", v)
+	}
+	if v := (&Pointable{}).TipPositionV(); v != (Vector{}) {
+		t.Fatalf("Received %v. Expected the zero Vector for an unset field", v)
+	}
+}
+
+func TestVectorMath(t *testing.T) {
+	a := Vector{1, 0, 0}
+	b := Vector{0, 3, 4}
+
+	if v := a.Add(b); v != (Vector{1, 3, 4}) {
+		t.Fatalf("Received %v. Expected [1 3 4]", v)
+	}
+	if v := b.Sub(a); v != (Vector{-1, 3, 4}) {
+		t.Fatalf("Received %v. Expected [-1 3 4]", v)
+	}
+	if v := b.Scale(2); v != (Vector{0, 6, 8}) {
+		t.Fatalf("Received %v. Expected [0 6 8]", v)
+	}
+	if d := a.Dot(b); d != 0 {
+		t.Fatalf("Received %f. Expected 0", d)
+	}
+	if v := a.Cross(Vector{0, 1, 0}); v != (Vector{0, 0, 1}) {
+		t.Fatalf("Received %v. Expected [0 0 1]", v)
+	}
+	if l := b.Length(); l != 5 {
+		t.Fatalf("Received %f. Expected 5", l)
+	}
+	if s := b.Slice(); len(s) != 3 || s[2] != 4 || s[0] != b.X() || s[1] != b.Y() || s[2] != b.Z() {
+		t.Fatalf("Received %v. Expected [0 3 4]", s)
+	}
+}
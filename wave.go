@@ -0,0 +1,104 @@
+package leapmotion
+
+import "time"
+
+// DefaultWaveSpeed is the palm speed along X, in millimeters per second, a
+// WaveDetector requires before it counts the direction of motion
+const DefaultWaveSpeed = 150
+
+// WaveDetector recognizes a wave, which the Leap Motion service doesn't: a
+// hand whose palm reverses its direction along the X axis Reversals times
+// within Window. Feed it every frame with Update.
+type WaveDetector struct {
+	// Reversals is how many changes of direction make a wave
+	Reversals int
+	// Window is the time the reversals must happen within
+	Window time.Duration
+	// MinSpeed is the palm speed along X below which motion is ignored, so
+	// jitter of a still hand doesn't count as reversals
+	MinSpeed float64
+
+	detector[*waveState]
+	onWave func(handID int)
+}
+
+type waveState struct {
+	direction int   // -1 or 1, 0 until the hand moves fast enough
+	reversals []int // frame timestamps (microseconds) of the reversals
+}
+
+// NewWaveDetector returns a WaveDetector firing after reversals changes of
+// direction within window
+func NewWaveDetector(reversals int, window time.Duration) *WaveDetector {
+	return &WaveDetector{
+		Reversals: reversals,
+		Window:    window,
+		MinSpeed:  DefaultWaveSpeed,
+	}
+}
+
+// OnWave registers cb to be called with the ID of a hand that waved
+func (d *WaveDetector) OnWave(cb func(handID int)) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	d.onWave = cb
+}
+
+// Update feeds frame to the detector, calling the OnWave callback for every
+// hand that completed a wave. The state of hands that left the frame is reset.
+func (d *WaveDetector) Update(frame *Frame) {
+	var waved []int
+
+	d.mu.Lock()
+	if !d.next(frame) {
+		d.mu.Unlock()
+		return
+	}
+
+	window := int(d.Window / time.Microsecond)
+	for _, hand := range frame.Hands {
+		st := d.hands.get(hand.ID, func() *waveState { return &waveState{} })
+
+		// Forget reversals that fell out of the window
+		var recent []int
+		for _, ts := range st.reversals {
+			if frame.Timestamp-ts <= window {
+				recent = append(recent, ts)
+			}
+		}
+		st.reversals = recent
+
+		if !isVector(hand.PalmVelocity) {
+			continue
+		}
+		vx := hand.PalmVelocity[0]
+		if vx > -d.MinSpeed && vx < d.MinSpeed {
+			continue
+		}
+
+		direction := 1
+		if vx < 0 {
+			direction = -1
+		}
+		if st.direction != 0 && direction != st.direction {
+			st.reversals = append(st.reversals, frame.Timestamp)
+		}
+		st.direction = direction
+
+		if len(st.reversals) >= d.Reversals {
+			st.reversals = nil
+			waved = append(waved, hand.ID)
+		}
+	}
+
+	d.hands.forgetAbsent(frame, nil)
+	cb := d.onWave
+	d.mu.Unlock()
+
+	if cb != nil {
+		for _, id := range waved {
+			cb(id)
+		}
+	}
+}
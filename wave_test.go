@@ -0,0 +1,41 @@
+package leapmotion
+
+import (
+	"testing"
+	"time"
+)
+
+func TestWaveDetector(t *testing.T) {
+	d := NewWaveDetector(3, time.Second)
+
+	waves := 0
+	d.OnWave(func(handID int) {
+		if handID != 1 {
+			t.Fatalf("Received wave of hand %d. Expected hand 1", handID)
+		}
+		waves++
+	})
+
+	frame := func(id, timestamp int, vx float64) *Frame {
+		return testFrame(id, timestamp, Hand{ID: 1, PalmVelocity: []float64{vx, 0, 0}})
+	}
+
+	velocities := []float64{300, 50, -300, -400, 300, 20, -300}
+	for i, vx := range velocities {
+		d.Update(frame(i, i*100000, vx))
+	}
+	if waves != 1 {
+		t.Fatalf("Received %d waves. Expected 1", waves)
+	}
+
+	// Reversals too far apart aren't a wave
+	waves = 0
+	d = NewWaveDetector(3, time.Second)
+	d.OnWave(func(int) { waves++ })
+	for i, vx := range []float64{300, -300, 300, -300} {
+		d.Update(frame(i, i*800000, vx))
+	}
+	if waves != 0 {
+		t.Fatalf("Received %d waves. Expected none", waves)
+	}
+}
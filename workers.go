@@ -0,0 +1,108 @@
+package leapmotion
+
+import "sync"
+
+// workerPool runs the frameHandler of a Client on several goroutines, as set
+// up by WithWorkers
+type workerPool struct {
+	handler func(*Frame)
+	ordered bool
+	jobs    chan *job
+	quit    chan struct{}
+	wg      sync.WaitGroup
+
+	mu     sync.Mutex
+	closed bool
+	last   chan struct{} // closed once the handler of the latest job returned
+}
+
+type job struct {
+	frame *Frame
+	prev  <-chan struct{} // with ordered, the job must wait for prev to finish
+	done  chan struct{}
+}
+
+func newWorkerPool(n int, ordered bool, handler func(*Frame)) *workerPool {
+	p := &workerPool{
+		handler: handler,
+		ordered: ordered,
+		jobs:    make(chan *job, messagesBuffer),
+		quit:    make(chan struct{}),
+	}
+
+	p.wg.Add(n)
+	for i := 0; i < n; i++ {
+		go func() {
+			defer p.wg.Done()
+			p.work()
+		}()
+	}
+
+	return p
+}
+
+func (p *workerPool) work() {
+	for {
+		select {
+		case j := <-p.jobs:
+			p.run(j)
+		case <-p.quit:
+			// Drain the frames queued before the pool was closed
+			for {
+				select {
+				case j := <-p.jobs:
+					p.run(j)
+				default:
+					return
+				}
+			}
+		}
+	}
+}
+
+func (p *workerPool) run(j *job) {
+	if j.prev != nil {
+		<-j.prev
+	}
+	p.handler(j.frame)
+	if j.done != nil {
+		close(j.done)
+	}
+}
+
+// submit queues frame for a worker, blocking while the queue is full. Frames
+// submitted once the pool is closed are dropped.
+func (p *workerPool) submit(frame *Frame) {
+	j := &job{frame: frame}
+
+	p.mu.Lock()
+	if p.closed {
+		p.mu.Unlock()
+		return
+	}
+	if p.ordered {
+		j.prev = p.last
+		j.done = make(chan struct{})
+		p.last = j.done
+	}
+	p.mu.Unlock()
+
+	select {
+	case p.jobs <- j:
+	case <-p.quit:
+	}
+}
+
+// close stops the workers once the queued frames have been handled and waits
+// for them
+func (p *workerPool) close() {
+	p.mu.Lock()
+	closed := p.closed
+	p.closed = true
+	p.mu.Unlock()
+
+	if !closed {
+		close(p.quit)
+	}
+	p.wg.Wait()
+}
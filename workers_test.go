@@ -0,0 +1,102 @@
+package leapmotion
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestWithWorkers(t *testing.T) {
+	transport := newFakeTransport()
+
+	var mu sync.Mutex
+	running, maxRunning, handled := 0, 0, 0
+	c, err := Connect(func(*Frame) {
+		mu.Lock()
+		running++
+		if running > maxRunning {
+			maxRunning = running
+		}
+		mu.Unlock()
+
+		time.Sleep(20 * time.Millisecond)
+
+		mu.Lock()
+		running--
+		handled++
+		mu.Unlock()
+	}, WithDialer(transport.dialer()), WithWorkers(4))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for id := 1; id <= 8; id++ {
+		transport.messages <- []byte(fmt.Sprintf(`{"id": %d}`, id))
+	}
+	time.Sleep(10 * time.Millisecond)
+	c.Close()
+
+	// Close drains the pool
+	mu.Lock()
+	defer mu.Unlock()
+	if handled != 8 {
+		t.Fatalf("Received %d handled frames. Expected Close to wait for all 8", handled)
+	}
+	if maxRunning < 2 {
+		t.Fatalf("Received %d concurrent handlers. Expected the frames to be handled concurrently", maxRunning)
+	}
+}
+
+func TestWithOrderedWorkers(t *testing.T) {
+	var mu sync.Mutex
+	var started []float64
+	c := newClient(func(frame *Frame) {
+		mu.Lock()
+		started = append(started, frame.ID)
+		mu.Unlock()
+		time.Sleep(time.Millisecond)
+	}, []Option{WithOrderedWorkers(4)})
+
+	for id := 1; id <= 20; id++ {
+		c.handleMessage([]byte(fmt.Sprintf(`{"id": %d}`, id)))
+	}
+	c.Close()
+
+	if len(started) != 20 {
+		t.Fatalf("Received %d handled frames. Expected 20", len(started))
+	}
+	for i, id := range started {
+		if id != float64(i+1) {
+			t.Fatalf("Received %v. Expected the handlers to start in frame order", started)
+		}
+	}
+}
+
+func TestWithOrderedWorkersCompletion(t *testing.T) {
+	var mu sync.Mutex
+	var completed []float64
+	c := newClient(func(frame *Frame) {
+		// Earlier frames take longer, so they'd finish last if handlers
+		// overlapped
+		time.Sleep(time.Duration(6-frame.ID) * 5 * time.Millisecond)
+		mu.Lock()
+		completed = append(completed, frame.ID)
+		mu.Unlock()
+	}, []Option{WithOrderedWorkers(4)})
+
+	for id := 1; id <= 5; id++ {
+		c.handleMessage([]byte(fmt.Sprintf(`{"id": %d}`, id)))
+	}
+	c.Close()
+
+	expected := []float64{1, 2, 3, 4, 5}
+	if len(completed) != len(expected) {
+		t.Fatalf("Received %v. Expected %v", completed, expected)
+	}
+	for i := range expected {
+		if completed[i] != expected[i] {
+			t.Fatalf("Received %v. Expected %v", completed, expected)
+		}
+	}
+}